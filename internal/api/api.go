@@ -22,7 +22,7 @@ type apiResponse struct {
 	Data    any    `json:"data,omitempty"`
 }
 
-func Serve(jwtSecret []byte, session *session.Session, port uint16) error {
+func Serve(jwtSecret []byte, port uint16) error {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.Default()
 	router.Use(gin.Logger())
@@ -32,17 +32,19 @@ func Serve(jwtSecret []byte, session *session.Session, port uint16) error {
 	apiGroup.Use(authMiddleware(jwtSecret))
 
 	// Session API
-	apiGroup.GET("/session", func(context *gin.Context) {
-		getSession(context, session)
-	})
+	apiGroup.GET("/session", getSession)
 
 	// Vaults API
 	vaultAPI := apiGroup.Group("/vaults")
-	vaultAPI.POST("", newVault)
+	vaultAPI.POST("", func(context *gin.Context) {
+		newVault(context, getSessionSecretKey())
+	})
 	vaultAPI.GET("", listDirForVaults)
-	vaultAPI.PUT("/:vaultFile", putItem)
+	vaultAPI.PUT("/:vaultFile", func(context *gin.Context) {
+		putItem(context, getSessionSecretKey())
+	})
 	vaultAPI.GET("/:vaultFile", func(context *gin.Context) {
-		getVault(context, session.SecretKey())
+		getVault(context, getSessionSecretKey())
 	})
 
 	// Environments API
@@ -64,10 +66,22 @@ func Serve(jwtSecret []byte, session *session.Session, port uint16) error {
 }
 
 func Run(port uint16) error {
-	session, err := session.GetSession()
-	if err != nil {
+	if _, err := session.GetSession(); err != nil {
 		utils.ExitOnError(err)
 	}
+	watcher, err := session.WatchSecretFiles(func(err error) {
+		if err != nil {
+			fmt.Println("Failed to reload the session:", err)
+		} else {
+			fmt.Println("Reloaded the session with the changed secret files")
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if watcher != nil {
+		defer watcher.Close()
+	}
 	jwtSecret := make([]byte, 32)
 	if _, err = rand.Read(jwtSecret); err != nil {
 		return err
@@ -85,5 +99,5 @@ func Run(port uint16) error {
 	}
 	url := fmt.Sprintf("%s:%d/#%s", slvLocalUrl, port, jwtSecretStr)
 	fmt.Printf("Open this URL in your browser: %s\n", url)
-	return Serve(jwtSecret, session, port)
+	return Serve(jwtSecret, port)
 }
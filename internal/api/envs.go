@@ -9,13 +9,23 @@ import (
 	"slv.sh/slv/internal/core/profiles"
 )
 
+// envResponse adds the public key fingerprint to an environment returned by the API.
+type envResponse struct {
+	*environments.Environment
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+func newEnvResponse(env *environments.Environment) envResponse {
+	return envResponse{Environment: env, Fingerprint: env.Fingerprint()}
+}
+
 func getSelf(context *gin.Context) {
 	self := environments.GetSelf()
 	if self == nil {
 		context.AbortWithStatusJSON(http.StatusNotFound, apiResponse{Success: false, Error: "self environment not found"})
 		return
 	}
-	context.JSON(http.StatusOK, apiResponse{Success: true, Data: self})
+	context.JSON(http.StatusOK, apiResponse{Success: true, Data: newEnvResponse(self)})
 }
 
 func setSelf(context *gin.Context) {
@@ -76,7 +86,15 @@ func getEnvs(context *gin.Context) {
 		context.AbortWithStatusJSON(http.StatusInternalServerError, apiResponse{Success: false, Error: err.Error()})
 		return
 	}
-	context.JSON(http.StatusOK, apiResponse{Success: true, Data: envs})
+	// Environments that are not signed by the root or an admin of the profile are left out, so that they are not
+	// offered for sharing vaults
+	envResponses := make([]envResponse, 0, len(envs))
+	for _, env := range envs {
+		if profile.VerifyEnv(env) == nil {
+			envResponses = append(envResponses, newEnvResponse(env))
+		}
+	}
+	context.JSON(http.StatusOK, apiResponse{Success: true, Data: envResponses})
 }
 
 func getEnvProviders(context *gin.Context) {
@@ -95,7 +113,7 @@ func getEnvProviders(context *gin.Context) {
 	providerIds := envproviders.ListIds()
 	providers := make(map[string]providerResponse)
 	for _, providerId := range providerIds {
-		if providerId != envproviders.PasswordProviderId {
+		if providerId != envproviders.PasswordProviderId && envproviders.IsBindingRequired(providerId) {
 			provider := providerResponse{
 				Id:          providerId,
 				Name:        envproviders.GetName(providerId),
@@ -130,6 +148,12 @@ func newEnv(context *gin.Context) {
 		context.AbortWithStatusJSON(http.StatusBadRequest, apiResponse{Success: false, Error: err.Error()})
 		return
 	}
+	if profile, err := profiles.GetActiveProfile(); err == nil {
+		if err = profile.CheckCreateEnv(); err != nil {
+			context.AbortWithStatusJSON(http.StatusForbidden, apiResponse{Success: false, Error: err.Error()})
+			return
+		}
+	}
 	envType := environments.SERVICE
 	omitBindingInESB := false
 	if request.ProviderId == "" {
@@ -146,7 +170,7 @@ func newEnv(context *gin.Context) {
 			return
 		}
 		context.JSON(http.StatusOK, apiResponse{Success: true, Data: map[string]any{
-			"env": env,
+			"env": newEnvResponse(env),
 			"sk":  sk.String(),
 			"esb": esb,
 		}})
@@ -169,7 +193,7 @@ func newEnv(context *gin.Context) {
 		env.SetEmail(request.Email)
 		env.AddTags(request.Tags...)
 		context.JSON(http.StatusOK, apiResponse{Success: true, Data: map[string]any{
-			"env": env,
+			"env": newEnvResponse(env),
 			"esb": esb,
 		}})
 		return
@@ -4,11 +4,20 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"slv.sh/slv/internal/core/crypto"
 	"slv.sh/slv/internal/core/session"
 )
 
-func getSession(context *gin.Context, session *session.Session) {
-	if session.SecretKey() == nil {
+// getSessionSecretKey returns the secret key of the current session, which may have been reloaded since the
+// server started.
+func getSessionSecretKey() *crypto.SecretKey {
+	secretKey, _ := session.GetSecretKey()
+	return secretKey
+}
+
+func getSession(context *gin.Context) {
+	session, err := session.GetSession()
+	if err != nil || session.SecretKey() == nil {
 		context.AbortWithStatusJSON(http.StatusUnauthorized, apiResponse{Success: false, Error: "Unauthorized"})
 		return
 	}
@@ -1,12 +1,15 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 	"os"
 	"path/filepath"
 
 	"github.com/gin-gonic/gin"
+	"slv.sh/slv/internal/core/audit"
 	"slv.sh/slv/internal/core/crypto"
+	"slv.sh/slv/internal/core/profiles"
 	"slv.sh/slv/internal/core/vaults"
 	"slv.sh/slv/internal/helpers"
 )
@@ -61,6 +64,10 @@ func getVault(context *gin.Context, secretKey *crypto.SecretKey) {
 		return
 	}
 	if context.Query("unlocked") == "true" || context.Query("unlock") == "true" {
+		if err = audit.Record(audit.API, &audit.Entry{Action: audit.Unlock, Vault: vaultFile}); err != nil {
+			context.AbortWithStatusJSON(http.StatusInternalServerError, apiResponse{Success: false, Error: err.Error()})
+			return
+		}
 		if err = vault.Unlock(secretKey); err != nil {
 			context.AbortWithStatusJSON(http.StatusInternalServerError, apiResponse{Success: false, Error: err.Error()})
 			return
@@ -78,7 +85,7 @@ type newVaultRequest struct {
 	PublicKeys   []string `json:"publicKeys,omitempty"`
 }
 
-func newVault(context *gin.Context) {
+func newVault(context *gin.Context, secretKey *crypto.SecretKey) {
 	var request newVaultRequest
 	if err := context.ShouldBindJSON(&request); err != nil {
 		context.AbortWithStatusJSON(http.StatusBadRequest, apiResponse{Success: false, Error: err.Error()})
@@ -93,6 +100,17 @@ func newVault(context *gin.Context) {
 		request.VaultFile = filepath.Join(dir, request.VaultFile)
 	}
 	vault, err := helpers.NewVault(request.VaultFile, request.Name, request.K8sNamespace, request.Hash, request.QuantumSafe, request.PublicKeys)
+	if errors.Is(err, profiles.ErrNotAllowed) {
+		context.AbortWithStatusJSON(http.StatusForbidden, apiResponse{Success: false, Error: err.Error()})
+		return
+	} else if err != nil {
+		context.AbortWithStatusJSON(http.StatusInternalServerError, apiResponse{Success: false, Error: err.Error()})
+		return
+	}
+	signed, err := helpers.SetVaultSigner(vault, secretKey)
+	if err == nil && signed {
+		err = vault.Sign()
+	}
 	if err != nil {
 		context.AbortWithStatusJSON(http.StatusInternalServerError, apiResponse{Success: false, Error: err.Error()})
 		return
@@ -100,7 +118,7 @@ func newVault(context *gin.Context) {
 	context.JSON(http.StatusOK, apiResponse{Success: true, Data: helpers.GetVaultInfo(vault, true, false)})
 }
 
-func putItem(context *gin.Context) {
+func putItem(context *gin.Context, secretKey *crypto.SecretKey) {
 	vaultFile := context.Param("vaultFile")
 	dir := context.Query("dir")
 	if dir != "" {
@@ -119,6 +137,18 @@ func putItem(context *gin.Context) {
 		context.AbortWithStatusJSON(http.StatusInternalServerError, apiResponse{Success: false, Error: err.Error()})
 		return
 	}
+	if _, err = helpers.SetVaultSigner(vault, secretKey); err != nil {
+		context.AbortWithStatusJSON(http.StatusInternalServerError, apiResponse{Success: false, Error: err.Error()})
+		return
+	}
+	var itemNames []string
+	for key := range request {
+		itemNames = append(itemNames, key)
+	}
+	if err = audit.Record(audit.API, &audit.Entry{Action: audit.Put, Vault: vaultFile, Items: itemNames}); err != nil {
+		context.AbortWithStatusJSON(http.StatusInternalServerError, apiResponse{Success: false, Error: err.Error()})
+		return
+	}
 	for key, item := range request {
 		if err = vault.Put(key, []byte(item.Value), !item.PlainText); err != nil {
 			context.AbortWithStatusJSON(http.StatusInternalServerError, apiResponse{Success: false, Error: err.Error()})
@@ -0,0 +1,121 @@
+package cmdagent
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/agent"
+	"slv.sh/slv/internal/core/session"
+)
+
+func getSocketPath(cmd *cobra.Command) string {
+	if socketPath, _ := cmd.Flags().GetString(agentSocketFlag.Name); socketPath != "" {
+		return socketPath
+	}
+	return agent.DefaultSocketPath()
+}
+
+func AgentCommand() *cobra.Command {
+	if agentCmd == nil {
+		agentCmd = &cobra.Command{
+			Use:   "agent",
+			Short: "Holds the unlocked secret key of an environment for other SLV commands",
+			Long: `Unlocks the secret key of the environment once (prompting for a password or calling a KMS as usual) and holds it
+in memory for the given time. Other SLV commands with SLV_AGENT_SOCK pointing at the agent's socket send
+decryption and signing requests to the agent instead of unlocking the secret key themselves, so the secret key
+never leaves the agent. Only the current user can connect to the socket.
+
+The agent runs in the foreground until the secret key expires or the agent is stopped, so run it in a terminal of
+its own or as a service. It prints the command that points other shells at it, as ssh-agent does.`,
+			Args: cobra.NoArgs,
+			Run: func(cmd *cobra.Command, args []string) {
+				socketPath := getSocketPath(cmd)
+				ttl, _ := cmd.Flags().GetDuration(agentTTLFlag.Name)
+				// The agent unlocks the secret key itself rather than asking an agent for it.
+				if err := agent.Unsetenv(); err != nil {
+					utils.ExitOnError(err)
+				}
+				secretKey, err := session.GetSecretKey()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				server, err := agent.NewServer(secretKey, socketPath, ttl)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				signals := make(chan os.Signal, 1)
+				signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+				go func() {
+					<-signals
+					server.Close()
+				}()
+				fmt.Println(agent.ExportCommand(socketPath))
+				if ttl > 0 {
+					fmt.Fprintln(os.Stderr, "Agent holding the secret key until", time.Now().Add(ttl).Format(time.RFC1123))
+				}
+				if err = server.Serve(); err != nil {
+					utils.ExitOnError(err)
+				}
+				utils.SafeExit()
+			},
+		}
+		agentCmd.PersistentFlags().String(agentSocketFlag.Name, "", agentSocketFlag.Usage)
+		agentCmd.Flags().Duration(agentTTLFlag.Name, time.Hour, agentTTLFlag.Usage)
+		agentCmd.AddCommand(agentStatusCommand())
+		agentCmd.AddCommand(agentStopCommand())
+	}
+	return agentCmd
+}
+
+func agentStatusCommand() *cobra.Command {
+	if agentStatusCmd == nil {
+		agentStatusCmd = &cobra.Command{
+			Use:     "status",
+			Aliases: []string{"show", "info"},
+			Short:   "Shows the environment whose secret key the agent holds",
+			Args:    cobra.NoArgs,
+			Run: func(cmd *cobra.Command, args []string) {
+				socketPath := getSocketPath(cmd)
+				status, err := agent.GetStatus(socketPath)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				fmt.Println("Socket:       ", socketPath)
+				fmt.Println("Public Key:   ", color.CyanString(status.PublicKeyEC))
+				fmt.Println("Public Key PQ:", color.CyanString(status.PublicKeyPQ))
+				if status.ExpiresAt != nil {
+					fmt.Println("Expires At:   ", status.ExpiresAt.Local().Format(time.RFC1123))
+				} else {
+					fmt.Println("Expires At:   ", "never")
+				}
+				utils.SafeExit()
+			},
+		}
+	}
+	return agentStatusCmd
+}
+
+func agentStopCommand() *cobra.Command {
+	if agentStopCmd == nil {
+		agentStopCmd = &cobra.Command{
+			Use:     "stop",
+			Aliases: []string{"kill"},
+			Short:   "Stops the agent, wiping the secret key it holds",
+			Args:    cobra.NoArgs,
+			Run: func(cmd *cobra.Command, args []string) {
+				if err := agent.Stop(getSocketPath(cmd)); err != nil {
+					utils.ExitOnError(err)
+				}
+				fmt.Println(color.GreenString("Agent stopped"))
+				utils.SafeExit()
+			},
+		}
+	}
+	return agentStopCmd
+}
@@ -0,0 +1,24 @@
+package cmdagent
+
+import (
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+)
+
+var (
+	agentCmd       *cobra.Command
+	agentStatusCmd *cobra.Command
+	agentStopCmd   *cobra.Command
+)
+
+var (
+	agentSocketFlag = utils.FlagDef{
+		Name:  "socket",
+		Usage: "Unix socket of the agent (defaults to SLV_AGENT_SOCK, or agent.sock in the app data directory)",
+	}
+
+	agentTTLFlag = utils.FlagDef{
+		Name:  "ttl",
+		Usage: "Time to hold the unlocked secret key for, after which the agent exits (0 holds it until the agent is stopped)",
+	}
+)
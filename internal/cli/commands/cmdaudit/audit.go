@@ -0,0 +1,86 @@
+package cmdaudit
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/audit"
+)
+
+func AuditCommand() *cobra.Command {
+	if auditCmd == nil {
+		auditCmd = &cobra.Command{
+			Use:   "audit",
+			Short: "Manage the local audit log of SLV operations",
+			Long: `The audit log records vault operations (put, delete, get/unlock, grant, revoke, ref and deref)
+performed through the CLI, API and TUI on this machine. Entries are hash-chained and signed with a key
+derived from the session environment's secret key. Auditing is opt-in unless the active profile requires it.`,
+			Run: func(cmd *cobra.Command, args []string) {
+				cmd.Help()
+			},
+		}
+		auditCmd.AddCommand(auditEnableCommand())
+		auditCmd.AddCommand(auditDisableCommand())
+		auditCmd.AddCommand(auditShowCommand())
+		auditCmd.AddCommand(auditVerifyCommand())
+		auditCmd.AddCommand(auditExportCommand())
+	}
+	return auditCmd
+}
+
+func auditEnableCommand() *cobra.Command {
+	if auditEnableCmd == nil {
+		auditEnableCmd = &cobra.Command{
+			Use:     "enable",
+			Aliases: []string{"on"},
+			Short:   "Enables the audit log on this machine",
+			Run: func(cmd *cobra.Command, args []string) {
+				if err := audit.SetEnabled(true); err != nil {
+					utils.ExitOnError(err)
+				}
+				fmt.Println("Audit log enabled:", color.GreenString(audit.LogFile()))
+			},
+		}
+	}
+	return auditEnableCmd
+}
+
+func auditDisableCommand() *cobra.Command {
+	if auditDisableCmd == nil {
+		auditDisableCmd = &cobra.Command{
+			Use:     "disable",
+			Aliases: []string{"off"},
+			Short:   "Disables the audit log on this machine",
+			Run: func(cmd *cobra.Command, args []string) {
+				if err := audit.SetEnabled(false); err != nil {
+					utils.ExitOnError(err)
+				}
+				fmt.Println(color.YellowString("Audit log disabled"))
+			},
+		}
+	}
+	return auditDisableCmd
+}
+
+func filterEntries(entries []*audit.Entry, vaultFile string, actions []string) []*audit.Entry {
+	if vaultFile != "" {
+		if absPath, err := filepath.Abs(vaultFile); err == nil {
+			vaultFile = absPath
+		}
+	}
+	var filtered []*audit.Entry
+	for _, entry := range entries {
+		if vaultFile != "" && entry.Vault != vaultFile {
+			continue
+		}
+		if len(actions) > 0 && !slices.Contains(actions, string(entry.Action)) {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	return filtered
+}
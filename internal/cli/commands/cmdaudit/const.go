@@ -0,0 +1,51 @@
+package cmdaudit
+
+import (
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+)
+
+var (
+	auditCmd        *cobra.Command
+	auditEnableCmd  *cobra.Command
+	auditDisableCmd *cobra.Command
+	auditShowCmd    *cobra.Command
+	auditVerifyCmd  *cobra.Command
+	auditExportCmd  *cobra.Command
+)
+
+var (
+	auditVaultFlag = utils.FlagDef{
+		Name:      "vault",
+		Shorthand: "v",
+		Usage:     "Shows only the entries for the given vault file",
+	}
+
+	auditActionFlag = utils.FlagDef{
+		Name:      "action",
+		Shorthand: "a",
+		Usage:     "Shows only the entries for the given actions [put, delete, get, unlock, grant, revoke, ref, deref, upgrade, encrypt, decrypt]",
+	}
+
+	auditLimitFlag = utils.FlagDef{
+		Name:      "limit",
+		Shorthand: "n",
+		Usage:     "Number of most recent entries to show (0 shows all)",
+	}
+
+	auditExportFormatFlag = utils.FlagDef{
+		Name:  "format",
+		Usage: "Export format as one of [jsonl, json, csv] (jsonl keeps the log verifiable)",
+	}
+
+	auditStrictFlag = utils.FlagDef{
+		Name:  "strict",
+		Usage: "Fails if any entry is signed by an untrusted key, or is unsigned after the first signed one",
+	}
+
+	auditOutputFlag = utils.FlagDef{
+		Name:      "output",
+		Shorthand: "o",
+		Usage:     "Writes the export to the given file instead of stdout",
+	}
+)
@@ -0,0 +1,89 @@
+package cmdaudit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/audit"
+)
+
+func auditExportCommand() *cobra.Command {
+	if auditExportCmd == nil {
+		auditExportCmd = &cobra.Command{
+			Use:   "export",
+			Short: "Exports the audit log",
+			Run: func(cmd *cobra.Command, args []string) {
+				vaultFile, _ := cmd.Flags().GetString(auditVaultFlag.Name)
+				actions, _ := cmd.Flags().GetStringSlice(auditActionFlag.Name)
+				format, _ := cmd.Flags().GetString(auditExportFormatFlag.Name)
+				outputFile, _ := cmd.Flags().GetString(auditOutputFlag.Name)
+				entries, err := audit.ReadEntries()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				entries = filterEntries(entries, vaultFile, actions)
+				var out io.Writer = os.Stdout
+				if outputFile != "" {
+					file, err := os.Create(outputFile)
+					if err != nil {
+						utils.ExitOnError(err)
+					}
+					defer file.Close()
+					out = file
+				}
+				switch strings.ToLower(format) {
+				case "", "jsonl":
+					encoder := json.NewEncoder(out)
+					for _, entry := range entries {
+						if err = encoder.Encode(entry); err != nil {
+							break
+						}
+					}
+				case "json":
+					encoder := json.NewEncoder(out)
+					encoder.SetIndent("", "  ")
+					err = encoder.Encode(entries)
+				case "csv":
+					err = writeEntriesCSV(out, entries)
+				default:
+					err = fmt.Errorf("invalid format: %s", format)
+				}
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				if outputFile != "" {
+					fmt.Printf("Exported %d audit log entries to %s\n", len(entries), outputFile)
+				}
+			},
+		}
+		auditExportCmd.Flags().StringP(auditVaultFlag.Name, auditVaultFlag.Shorthand, "", auditVaultFlag.Usage)
+		auditExportCmd.Flags().StringSliceP(auditActionFlag.Name, auditActionFlag.Shorthand, []string{}, auditActionFlag.Usage)
+		auditExportCmd.Flags().String(auditExportFormatFlag.Name, "jsonl", auditExportFormatFlag.Usage)
+		auditExportCmd.Flags().StringP(auditOutputFlag.Name, auditOutputFlag.Shorthand, "", auditOutputFlag.Usage)
+	}
+	return auditExportCmd
+}
+
+func writeEntriesCSV(out io.Writer, entries []*audit.Entry) error {
+	w := csv.NewWriter(out)
+	if err := w.Write([]string{"seq", "time", "source", "action", "vault", "items", "envs", "file", "actor", "signer", "prev_hash", "hash", "sig"}); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := w.Write([]string{strconv.FormatUint(entry.Seq, 10), entry.Time.Format(time.RFC3339Nano), string(entry.Source),
+			string(entry.Action), entry.Vault, strings.Join(entry.Items, ";"), strings.Join(entry.Envs, ";"), entry.File,
+			entry.Actor, entry.Signer, entry.PrevHash, entry.Hash, entry.Sig}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
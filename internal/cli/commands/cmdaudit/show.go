@@ -0,0 +1,104 @@
+package cmdaudit
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/audit"
+	"slv.sh/slv/internal/core/profiles"
+)
+
+func auditShowCommand() *cobra.Command {
+	if auditShowCmd == nil {
+		auditShowCmd = &cobra.Command{
+			Use:     "show",
+			Aliases: []string{"list", "ls", "view", "log"},
+			Short:   "Shows the entries in the audit log",
+			Run: func(cmd *cobra.Command, args []string) {
+				vaultFile, _ := cmd.Flags().GetString(auditVaultFlag.Name)
+				actions, _ := cmd.Flags().GetStringSlice(auditActionFlag.Name)
+				limit, _ := cmd.Flags().GetInt(auditLimitFlag.Name)
+				entries, err := audit.ReadEntries()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				entries = filterEntries(entries, vaultFile, actions)
+				if len(entries) == 0 {
+					fmt.Println("No audit log entries found.")
+					utils.SafeExit()
+				}
+				if limit > 0 && len(entries) > limit {
+					entries = entries[len(entries)-limit:]
+				}
+				profile, _ := profiles.GetActiveProfile()
+				entryTable := table.NewWriter()
+				entryTable.SetOutputMirror(os.Stdout)
+				entryTable.AppendHeader(table.Row{
+					text.Colors{text.Bold}.Sprint("Seq"),
+					text.Colors{text.Bold}.Sprint("Time"),
+					text.Colors{text.Bold}.Sprint("Source"),
+					text.Colors{text.Bold}.Sprint("Action"),
+					text.Colors{text.Bold}.Sprint("Vault"),
+					text.Colors{text.Bold}.Sprint("Details"),
+					text.Colors{text.Bold}.Sprint("Actor"),
+				})
+				for _, entry := range entries {
+					entryTable.AppendRow(table.Row{
+						entry.Seq,
+						entry.Time.Local().Format(time.DateTime),
+						entry.Source,
+						entry.Action,
+						entry.Vault,
+						getEntryDetails(entry),
+						getActorName(profile, entry.Actor),
+					})
+				}
+				entryTable.SetStyle(table.StyleLight)
+				entryTable.SetColumnConfigs([]table.ColumnConfig{
+					{Number: 1, Align: text.AlignRight},
+					{Number: 5, WidthMax: 50},
+					{Number: 6, WidthMax: 40},
+					{Number: 7, WidthMax: 30},
+				})
+				entryTable.Render()
+				utils.SafeExit()
+			},
+		}
+		auditShowCmd.Flags().StringP(auditVaultFlag.Name, auditVaultFlag.Shorthand, "", auditVaultFlag.Usage)
+		auditShowCmd.Flags().StringSliceP(auditActionFlag.Name, auditActionFlag.Shorthand, []string{}, auditActionFlag.Usage)
+		auditShowCmd.Flags().IntP(auditLimitFlag.Name, auditLimitFlag.Shorthand, 50, auditLimitFlag.Usage)
+	}
+	return auditShowCmd
+}
+
+func getEntryDetails(entry *audit.Entry) string {
+	var details []string
+	if len(entry.Items) > 0 {
+		details = append(details, "items: "+strings.Join(entry.Items, ", "))
+	}
+	if len(entry.Envs) > 0 {
+		details = append(details, fmt.Sprintf("envs: %d", len(entry.Envs)))
+	}
+	if entry.File != "" {
+		details = append(details, "file: "+entry.File)
+	}
+	return strings.Join(details, "\n")
+}
+
+func getActorName(profile *profiles.Profile, actor string) string {
+	if actor == "" {
+		return text.Colors{text.FgYellow}.Sprint("(unsigned)")
+	}
+	if profile != nil {
+		if env, _ := profile.GetEnv(actor); env != nil && env.Name != "" {
+			return env.Name
+		}
+	}
+	return actor
+}
@@ -0,0 +1,54 @@
+package cmdaudit
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/audit"
+	"slv.sh/slv/internal/core/crypto"
+	"slv.sh/slv/internal/core/session"
+)
+
+func auditVerifyCommand() *cobra.Command {
+	if auditVerifyCmd == nil {
+		auditVerifyCmd = &cobra.Command{
+			Use:     "verify",
+			Aliases: []string{"check", "validate"},
+			Short:   "Verifies the hash chain and signatures of the audit log",
+			Long: `Verifies the hash chain of the audit log and the signatures of its entries.
+Entries are signed with the signing key of the environment that recorded them and name that key, so anyone can check
+the signatures. A signature is trusted if the key belongs to an environment of the active profile that the profile
+trusts, or to the session environment.
+
+Unless --strict=false is given, the verification fails if any entry is signed by a key that is not trusted, or if an
+entry after the first signed one is unsigned.`,
+			Run: func(cmd *cobra.Command, args []string) {
+				var secretKeys []*crypto.SecretKey
+				if sess, err := session.GetSession(); err == nil {
+					secretKeys = sess.SecretKeys()
+				}
+				result, err := audit.Verify(audit.TrustedSigners(secretKeys...))
+				if err != nil {
+					utils.ExitOnError(fmt.Errorf("audit log verification failed after %d valid entries: %w", result.Entries, err))
+				}
+				fmt.Printf("Audit log verified: %s entries in an intact hash chain\n", color.GreenString("%d", result.Entries))
+				fmt.Printf("  Signatures verified with trusted environments: %d\n", result.Verified)
+				if result.Untrusted > 0 {
+					fmt.Println(color.YellowString("  Signed by keys of untrusted environments: %d", result.Untrusted))
+				}
+				if result.Unsigned > 0 {
+					fmt.Println(color.YellowString("  Unsigned entries: %d", result.Unsigned))
+				}
+				if strict, _ := cmd.Flags().GetBool(auditStrictFlag.Name); strict && result.Unverifiable > 0 {
+					utils.ExitOnErrorWithMessage(fmt.Sprintf("%d entries are signed by untrusted keys or are unsigned after the first signed entry (use --%s=false to only report them)",
+						result.Unverifiable, auditStrictFlag.Name))
+				}
+				utils.SafeExit()
+			},
+		}
+		auditVerifyCmd.Flags().Bool(auditStrictFlag.Name, true, auditStrictFlag.Usage)
+	}
+	return auditVerifyCmd
+}
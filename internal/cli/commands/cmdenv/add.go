@@ -26,9 +26,6 @@ func envAddCommand() *cobra.Command {
 				}
 				envdef, _ := cmd.Flags().GetString(envDefFlag.Name)
 				setAsRoot, _ := cmd.Flags().GetBool(envSetRootFlag.Name)
-				if setAsRoot && len(envdef) > 1 {
-					utils.ExitOnError(fmt.Errorf("cannot set more than one environment as root"))
-				}
 				var successMessage string
 				var env *environments.Environment
 				if env, err = environments.FromDefStr(envdef); err == nil && env != nil {
@@ -46,6 +43,11 @@ func envAddCommand() *cobra.Command {
 					successMessage = fmt.Sprintf("Successfully added environment to profile (%s)", color.GreenString(profile.Name()))
 				}
 				fmt.Println(successMessage)
+				if !setAsRoot {
+					signProfileEnv(profile, env)
+				} else if env.SigningKey != "" {
+					fmt.Println(color.YellowString("Only the environments signed by the root or its admins can be shared with from now on; sign the existing ones using 'slv profile sign'"))
+				}
 				utils.SafeExit()
 			},
 		}
@@ -0,0 +1,148 @@
+package cmdenv
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/environments"
+	"slv.sh/slv/internal/core/input"
+	"slv.sh/slv/internal/core/session"
+)
+
+func envBackupCommand() *cobra.Command {
+	if envBackupCmd == nil {
+		envBackupCmd = &cobra.Command{
+			Use:   "backup",
+			Short: "Backs up the secret key of an environment outside of its provider",
+			Long: `Backs up the secret key of the environment of the current session, so that it can be recovered with
+'slv env recover' if the provider that binds it is lost (a lost password, hardware key or cloud account).
+
+The secret key can be written down as a mnemonic of BIP39 words, or split into Shamir shares of which any given
+number reconstruct the key. Each share is encrypted to the public key of the environment that holds it.`,
+			Run: func(cmd *cobra.Command, args []string) {
+				cmd.Help()
+			},
+		}
+		envBackupCmd.AddCommand(envBackupMnemonicCommand())
+		envBackupCmd.AddCommand(envBackupSharesCommand())
+		envBackupCmd.AddCommand(envBackupOpenShareCommand())
+	}
+	return envBackupCmd
+}
+
+func envBackupMnemonicCommand() *cobra.Command {
+	if envBackupMnemonicCmd == nil {
+		envBackupMnemonicCmd = &cobra.Command{
+			Use:     "mnemonic",
+			Aliases: []string{"words", "paper"},
+			Short:   "Shows the environment secret key as a mnemonic to be written down",
+			Args:    cobra.NoArgs,
+			Run: func(cmd *cobra.Command, args []string) {
+				secretKey, err := session.GetSecretKey()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				mnemonic, err := environments.NewMnemonicBackup(secretKey)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				confirm, err := input.GetConfirmation("The mnemonic gives full access to the environment. Show it [yes/no]: ", "yes")
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				if !confirm {
+					utils.SafeExit()
+				}
+				words := strings.Fields(mnemonic)
+				for i, word := range words {
+					fmt.Printf("%3d. %-10s", i+1, word)
+					if (i+1)%4 == 0 || i == len(words)-1 {
+						fmt.Println()
+					}
+				}
+				fmt.Println(color.YellowString("Store the words offline in a safe place. They are not shown again."))
+				utils.SafeExit()
+			},
+		}
+	}
+	return envBackupMnemonicCmd
+}
+
+func envBackupSharesCommand() *cobra.Command {
+	if envBackupSharesCmd == nil {
+		envBackupSharesCmd = &cobra.Command{
+			Use:     "shares",
+			Aliases: []string{"shamir", "split"},
+			Short:   "Splits the environment secret key into recovery shares held by other environments",
+			Long: `Splits the environment secret key into one recovery share for each of the given environments. Any --threshold
+of the shares reconstruct the secret key, while fewer reveal nothing about it. Each share is encrypted to the public
+key of its holder, who can open it with 'slv env backup open-share' to hand it over for recovery.`,
+			Args: cobra.NoArgs,
+			Run: func(cmd *cobra.Command, args []string) {
+				secretKey, err := session.GetSecretKey()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				threshold, _ := cmd.Flags().GetInt(envBackupThresholdFlag.Name)
+				holders, err := GetPublicKeys(cmd, false, false)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				if threshold < 2 || threshold > len(holders) {
+					utils.ExitOnErrorWithMessage(fmt.Sprintf("the threshold must be between 2 and the number of share holders (%d)", len(holders)))
+				}
+				recoveryShares, err := environments.NewRecoveryShares(secretKey, threshold, holders)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				fmt.Printf("Any %d of the following %d recovery shares reconstruct the secret key:\n", threshold, len(holders))
+				for i, holder := range holders {
+					holderStr, err := holder.String()
+					if err != nil {
+						utils.ExitOnError(err)
+					}
+					fmt.Println()
+					fmt.Println("Holder:", color.CyanString(holderStr))
+					fmt.Println(recoveryShares[i])
+				}
+				utils.SafeExit()
+			},
+		}
+		envBackupSharesCmd.Flags().IntP(envBackupThresholdFlag.Name, envBackupThresholdFlag.Shorthand, 0, envBackupThresholdFlag.Usage)
+		envBackupSharesCmd.MarkFlagRequired(envBackupThresholdFlag.Name)
+		envBackupSharesCmd.Flags().StringSliceP(EnvPublicKeysFlag.Name, EnvPublicKeysFlag.Shorthand, []string{}, "Public keys (or their fingerprints from the active profile) of environments to hold the shares")
+		envBackupSharesCmd.Flags().StringSliceP(EnvSearchFlag.Name, EnvSearchFlag.Shorthand, []string{}, EnvSearchFlag.Usage)
+		envBackupSharesCmd.Flags().Bool(EnvSelfFlag.Name, false, EnvSelfFlag.Usage)
+		envBackupSharesCmd.Flags().Bool(EnvK8sFlag.Name, false, EnvK8sFlag.Usage)
+	}
+	return envBackupSharesCmd
+}
+
+func envBackupOpenShareCommand() *cobra.Command {
+	if envBackupOpenShareCmd == nil {
+		envBackupOpenShareCmd = &cobra.Command{
+			Use:     "open-share <recovery share>",
+			Aliases: []string{"open"},
+			Short:   "Opens a recovery share held by the environment of the current session",
+			Long: `Decrypts a recovery share held by the environment of the current session and shows it as words, to be handed
+over to whoever recovers the environment that was backed up.`,
+			Args: cobra.ExactArgs(1),
+			Run: func(cmd *cobra.Command, args []string) {
+				secretKey, err := session.GetSecretKey()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				share, err := environments.OpenRecoveryShare(args[0], secretKey)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				fmt.Println(share)
+				utils.SafeExit()
+			},
+		}
+	}
+	return envBackupOpenShareCmd
+}
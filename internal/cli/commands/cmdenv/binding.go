@@ -0,0 +1,273 @@
+package cmdenv
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/environments"
+	"slv.sh/slv/internal/core/environments/envproviders"
+	"slv.sh/slv/internal/core/input"
+	"slv.sh/slv/internal/core/profiles"
+)
+
+func envBindingCommand() *cobra.Command {
+	if envBindingCmd == nil {
+		envBindingCmd = &cobra.Command{
+			Use:     "binding",
+			Aliases: []string{"bindings", "bind"},
+			Short:   "Manage the providers that bind the secret key of an environment",
+			Long: `A secret binding can hold several independent bindings of the same environment secret key, for example
+AWS KMS, an offline RSA key and a password. They are tried in order until one of them succeeds, so the
+environment stays usable when one provider is unavailable. Set SLV_ENV_SECRET_BINDING_ORDER to a comma
+separated list of provider IDs to try those providers first.
+
+Adding or removing a binding does not change the public key of the environment.`,
+			Run: func(cmd *cobra.Command, args []string) {
+				cmd.Help()
+			},
+		}
+		envBindingCmd.PersistentFlags().String(envSecretBindingFlag.Name, "", envSecretBindingFlag.Usage)
+		envBindingCmd.AddCommand(envBindingListCommand())
+		envBindingCmd.AddCommand(envBindingAddCommand())
+		envBindingCmd.AddCommand(envBindingRemoveCommand())
+	}
+	return envBindingCmd
+}
+
+// getSecretBindingToManage returns the secret binding given by the flag, or that of the self environment
+// along with the self environment.
+func getSecretBindingToManage(cmd *cobra.Command) (string, *environments.Environment) {
+	if secretBinding, _ := cmd.Flags().GetString(envSecretBindingFlag.Name); secretBinding != "" {
+		return secretBinding, nil
+	}
+	selfEnv := environments.GetSelf()
+	if selfEnv == nil {
+		utils.ExitOnErrorWithMessage("no self environment registered: use --" + envSecretBindingFlag.Name + " to manage a secret binding")
+	}
+	return selfEnv.SecretBinding, selfEnv
+}
+
+func showSecretBindingProviders(secretBinding string) {
+	providerIds, err := envproviders.ListSecretBindingProviders(secretBinding)
+	if err != nil {
+		utils.ExitOnError(err)
+	}
+	if len(providerIds) == 0 {
+		fmt.Println("The secret key is held by a provider that needs no secret binding")
+		return
+	}
+	fmt.Println("Providers (in the order they are tried):")
+	for i, providerId := range providerIds {
+		fmt.Printf("  %d. %s (%s)\n", i+1, providerId, envproviders.GetName(providerId))
+	}
+}
+
+// saveSecretBinding replaces the previous secret binding with the new one in the self environment and in the
+// environments of the active profile, and then drops what the providers cached for the previous secret binding.
+func saveSecretBinding(previousSecretBinding, secretBinding string, selfEnv *environments.Environment) {
+	showSecretBindingProviders(secretBinding)
+	if selfEnv != nil {
+		selfEnv.SecretBinding = secretBinding
+		if err := selfEnv.SetAsSelf(); err != nil {
+			utils.ExitOnError(err)
+		}
+		fmt.Println(color.GreenString("Updated the secret binding of the self environment"))
+	}
+	updateSecretBindingInProfile(previousSecretBinding, secretBinding)
+	// An empty secret binding refers to the local keyring, which only the self environment may use.
+	if selfEnv != nil || previousSecretBinding != "" {
+		if err := envproviders.ForgetSecretBinding(previousSecretBinding, secretBinding); err != nil {
+			fmt.Println(color.YellowString("Failed to remove the locally cached secrets of the previous secret binding: " + err.Error()))
+		}
+	}
+	if secretBinding != "" {
+		fmt.Println("Secret Binding:", secretBinding)
+		if selfEnv == nil {
+			fmt.Println(color.YellowString("Please replace the previous secret binding with the one above wherever it is used."))
+		}
+	}
+}
+
+func updateSecretBindingInProfile(previousSecretBinding, secretBinding string) {
+	if previousSecretBinding == "" {
+		return
+	}
+	profile, err := profiles.GetActiveProfile()
+	if err != nil || !profile.IsPushSupported() {
+		return
+	}
+	envs, err := profile.ListEnvs()
+	if err != nil {
+		utils.ExitOnError(err)
+	}
+	for _, env := range envs {
+		if env.SecretBinding != previousSecretBinding {
+			continue
+		}
+		env.SecretBinding = secretBinding
+		if err = profile.PutEnv(env); err != nil {
+			utils.ExitOnError(fmt.Errorf("failed to update the environment %s in profile (%s): %w", env.Name, profile.Name(), err))
+		}
+		fmt.Printf("Updated the secret binding of the environment %s in profile (%s)\n", env.Name, color.GreenString(profile.Name()))
+	}
+}
+
+func envBindingListCommand() *cobra.Command {
+	if envBindingListCmd == nil {
+		envBindingListCmd = &cobra.Command{
+			Use:     "list",
+			Aliases: []string{"ls", "show"},
+			Short:   "Lists the providers of a secret binding",
+			Args:    cobra.NoArgs,
+			Run: func(cmd *cobra.Command, args []string) {
+				secretBinding, _ := getSecretBindingToManage(cmd)
+				showSecretBindingProviders(secretBinding)
+				utils.SafeExit()
+			},
+		}
+	}
+	return envBindingListCmd
+}
+
+func envBindingAddCommand() *cobra.Command {
+	if envBindingAddCmd == nil {
+		envBindingAddCmd = &cobra.Command{
+			Use:     "add",
+			Aliases: []string{"new"},
+			Short:   "Binds the environment secret key with one more provider",
+			Long: `Unbinds the environment secret key with the existing secret binding and binds it with one more provider.
+The new binding is tried after the existing ones.`,
+			Run: func(cmd *cobra.Command, args []string) {
+				cmd.Help()
+			},
+		}
+		providerIds := envproviders.ListIds()
+		sort.Strings(providerIds)
+		for _, providerId := range append(providerIds, pluginProviderCommandName) {
+			envBindingAddCmd.AddCommand(getEnvBindingAddProviderCommand(providerId))
+		}
+	}
+	return envBindingAddCmd
+}
+
+// addProviderInputFlags adds a flag for every argument of the provider. Passwords are prompted for instead.
+func addProviderInputFlags(cmd *cobra.Command, providerId string) {
+	if providerId == pluginProviderCommandName {
+		cmd.Flags().StringToString(envProviderInputFlag.Name, nil, envProviderInputFlag.Usage)
+		return
+	}
+	if providerId == envproviders.PasswordProviderId {
+		return
+	}
+	for _, arg := range envproviders.GetArgs(providerId) {
+		cmd.Flags().StringP(arg.Id(), "", "", arg.Description())
+		if arg.Required() {
+			cmd.MarkFlagRequired(arg.Id())
+		}
+	}
+}
+
+func getProviderInputs(cmd *cobra.Command, providerId string) map[string]string {
+	if cmd.Flags().Lookup(envProviderInputFlag.Name) != nil {
+		inputs, err := cmd.Flags().GetStringToString(envProviderInputFlag.Name)
+		if err != nil {
+			utils.ExitOnError(err)
+		}
+		return inputs
+	}
+	inputs := make(map[string]string)
+	if providerId == envproviders.PasswordProviderId {
+		password, err := input.NewPasswordFromUser(input.DefaultPasswordPolicy())
+		if err != nil {
+			utils.ExitOnError(err)
+		}
+		inputs["password"] = string(password)
+		return inputs
+	}
+	for _, arg := range envproviders.GetArgs(providerId) {
+		if value, _ := cmd.Flags().GetString(arg.Id()); value != "" {
+			inputs[arg.Id()] = value
+		}
+	}
+	return inputs
+}
+
+// newProviderCommand returns the subcommand of the provider with the given short description prefix, which runs with
+// the ID of the provider. Provider plugins are not described just to build the command tree, so they share a
+// "plugin <provider id>" subcommand that takes their inputs with --input.
+func newProviderCommand(providerId, descPrefix string, run func(cmd *cobra.Command, providerId string)) *cobra.Command {
+	providerCmd := &cobra.Command{
+		Use:   providerId,
+		Short: descPrefix + envproviders.GetName(providerId),
+		Long:  descPrefix + envproviders.GetDesc(providerId),
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			run(cmd, providerId)
+		},
+	}
+	if providerId == pluginProviderCommandName {
+		providerCmd.Use = pluginProviderCommandName + " <provider id>"
+		providerCmd.Short = descPrefix + "a provider plugin"
+		providerCmd.Long = descPrefix + "the provider plugin configured for the given ID in providers.yaml"
+		providerCmd.Args = cobra.ExactArgs(1)
+		providerCmd.Run = func(cmd *cobra.Command, args []string) {
+			run(cmd, args[0])
+		}
+	}
+	addProviderInputFlags(providerCmd, providerId)
+	return providerCmd
+}
+
+func getEnvBindingAddProviderCommand(providerId string) *cobra.Command {
+	return newProviderCommand(providerId, "Binds the environment secret key with ", func(cmd *cobra.Command, providerId string) {
+		secretBinding, selfEnv := getSecretBindingToManage(cmd)
+		if selfEnv == nil && !envproviders.IsBindingRequired(providerId) {
+			utils.ExitOnErrorWithMessage("the " + providerId + " provider can only bind the secret key of the self environment")
+		}
+		newSecretBinding, err := envproviders.AddSecretBinding(secretBinding, providerId, getProviderInputs(cmd, providerId))
+		if err != nil {
+			utils.ExitOnError(err)
+		}
+		saveSecretBinding(secretBinding, newSecretBinding, selfEnv)
+		utils.SafeExit()
+	})
+}
+
+func envBindingRemoveCommand() *cobra.Command {
+	if envBindingRmCmd == nil {
+		envBindingRmCmd = &cobra.Command{
+			Use:     "rm <provider>",
+			Aliases: []string{"remove", "del", "delete"},
+			Short:   "Removes the bindings of a provider from a secret binding",
+			Args:    cobra.ExactArgs(1),
+			ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+				if len(args) > 0 {
+					return nil, cobra.ShellCompDirectiveNoFileComp
+				}
+				secretBinding, _ := cmd.Flags().GetString(envSecretBindingFlag.Name)
+				if secretBinding == "" {
+					if selfEnv := environments.GetSelf(); selfEnv != nil {
+						secretBinding = selfEnv.SecretBinding
+					}
+				}
+				providerIds, _ := envproviders.ListSecretBindingProviders(secretBinding)
+				return providerIds, cobra.ShellCompDirectiveNoFileComp
+			},
+			Run: func(cmd *cobra.Command, args []string) {
+				secretBinding, selfEnv := getSecretBindingToManage(cmd)
+				providerId := strings.TrimSpace(args[0])
+				newSecretBinding, err := envproviders.RemoveSecretBinding(secretBinding, providerId)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				saveSecretBinding(secretBinding, newSecretBinding, selfEnv)
+				utils.SafeExit()
+			},
+		}
+	}
+	return envBindingRmCmd
+}
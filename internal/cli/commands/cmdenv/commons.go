@@ -3,6 +3,7 @@ package cmdenv
 import (
 	"fmt"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"slv.sh/slv/internal/cli/commands/utils"
 	"slv.sh/slv/internal/core/config"
@@ -12,6 +13,62 @@ import (
 	"slv.sh/slv/internal/core/session"
 )
 
+// resolvePublicKey parses the given public key, or resolves it against the given profile if it is a public key fingerprint.
+func resolvePublicKey(profile *profiles.Profile, publicKeyOrFingerprint string) (*crypto.PublicKey, error) {
+	publicKey, err := crypto.PublicKeyFromString(publicKeyOrFingerprint)
+	if err == nil {
+		return publicKey, nil
+	}
+	if _, fingerprintErr := crypto.ParseFingerprint(publicKeyOrFingerprint); fingerprintErr != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, fmt.Errorf("an active profile is required to resolve the fingerprint %s", publicKeyOrFingerprint)
+	}
+	env, err := profile.GetEnvByFingerprint(publicKeyOrFingerprint)
+	if err != nil {
+		return nil, err
+	}
+	if env == nil {
+		return nil, fmt.Errorf("no environment found in profile %s for the fingerprint %s", profile.Name(), publicKeyOrFingerprint)
+	}
+	if err = verifyProfileEnv(profile, env); err != nil {
+		return nil, err
+	}
+	return env.GetPublicKey()
+}
+
+// verifyProfileEnv refuses environments of the profile that are not signed by its root or one of its admins.
+func verifyProfileEnv(profile *profiles.Profile, env *environments.Environment) error {
+	if err := profile.VerifyEnv(env); err != nil {
+		return fmt.Errorf("refusing environment %s (%s): %w", env.Name, env.PublicKey, err)
+	}
+	return nil
+}
+
+// checkCreateEnv exits if the settings of the active profile, if any, do not allow creating environments.
+func checkCreateEnv() {
+	if profile, err := profiles.GetActiveProfile(); err == nil {
+		if err = profile.CheckCreateEnv(); err != nil {
+			utils.ExitOnError(err)
+		}
+	}
+}
+
+// signProfileEnv signs the environment just added to the profile if the current session belongs to the root or an
+// admin of the profile, and points out that it needs signing otherwise.
+func signProfileEnv(profile *profiles.Profile, env *environments.Environment) {
+	if signed, err := profile.IsSigned(); err != nil || !signed || profile.VerifyEnv(env) == nil {
+		return
+	}
+	if secretKeys, err := session.GetSecretKeys(); err == nil && profile.SignEnvs(secretKeys, env.PublicKey) == nil {
+		fmt.Printf("Signed environment %s in profile %s\n", color.GreenString(env.Name), color.GreenString(profile.Name()))
+		return
+	}
+	fmt.Println(color.YellowString("The environment %s can not be shared with until the root or an admin of profile %s signs it using 'slv profile sign'",
+		env.Name, profile.Name()))
+}
+
 func GetPublicKeys(cmd *cobra.Command, root, pq bool) (publicKeys []*crypto.PublicKey, err error) {
 	publicKeyStrings, err := cmd.Flags().GetStringSlice(EnvPublicKeysFlag.Name)
 	if err != nil {
@@ -27,23 +84,26 @@ func GetPublicKeys(cmd *cobra.Command, root, pq bool) (publicKeys []*crypto.Publ
 		return nil, fmt.Errorf("specify at least one of the following flags:\n --%s [search keyword]\n --%s [env public key]\n --%s\n --%s",
 			EnvSearchFlag.Name, EnvPublicKeysFlag.Name, EnvSelfFlag.Name, EnvK8sFlag.Name)
 	}
+	profile, err := profiles.GetActiveProfile()
+	if err != nil && len(queries) > 0 {
+		return nil, err
+	}
 	for _, pubKeyStr := range publicKeyStrings {
-		publicKey, err := crypto.PublicKeyFromString(pubKeyStr)
+		publicKey, err := resolvePublicKey(profile, pubKeyStr)
 		if err != nil {
 			return nil, err
 		}
 		publicKeys = append(publicKeys, publicKey)
 	}
-	profile, err := profiles.GetActiveProfile()
-	if err != nil && len(queries) > 0 {
-		return nil, err
-	}
 	if len(queries) > 0 {
 		envs, err := profile.SearchEnvs(queries)
 		if err != nil {
 			return nil, err
 		}
 		for _, env := range envs {
+			if err = verifyProfileEnv(profile, env); err != nil {
+				return nil, err
+			}
 			publicKey, err := crypto.PublicKeyFromString(env.PublicKey)
 			if err != nil {
 				return nil, err
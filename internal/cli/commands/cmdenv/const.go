@@ -5,6 +5,10 @@ import (
 	"slv.sh/slv/internal/cli/commands/utils"
 )
 
+const (
+	pluginProviderCommandName = "plugin"
+)
+
 var (
 	envCmd                     *cobra.Command
 	envNewCmd                  *cobra.Command
@@ -19,6 +23,18 @@ var (
 	envShowRootCmd             *cobra.Command
 	envShowSelfCmd             *cobra.Command
 	envShowK8sCmd              *cobra.Command
+	envOffboardCmd             *cobra.Command
+	envBindingCmd              *cobra.Command
+	envBindingListCmd          *cobra.Command
+	envBindingAddCmd           *cobra.Command
+	envBindingRmCmd            *cobra.Command
+	envRebindCmd               *cobra.Command
+	envRotateCmd               *cobra.Command
+	envBackupCmd               *cobra.Command
+	envBackupMnemonicCmd       *cobra.Command
+	envBackupSharesCmd         *cobra.Command
+	envBackupOpenShareCmd      *cobra.Command
+	envRecoverCmd              *cobra.Command
 )
 
 var (
@@ -75,11 +91,62 @@ var (
 	EnvPublicKeysFlag = utils.FlagDef{
 		Name:      "env-pubkey",
 		Shorthand: "k",
-		Usage:     "Public keys of environments that can access the vault",
+		Usage:     "Public keys (or their fingerprints from the active profile) of environments that can access the vault",
+	}
+
+	envOffboardDirFlag = utils.FlagDef{
+		Name:      "dir",
+		Shorthand: "d",
+		Usage:     "Directories to scan recursively for vaults (in addition to the vault directories registered with the active profile)",
+	}
+
+	envOffboardDryRunFlag = utils.FlagDef{
+		Name:  "dry-run",
+		Usage: "Shows the vaults that would be changed without modifying them",
+	}
+
+	envOffboardForceFlag = utils.FlagDef{
+		Name:  "force",
+		Usage: "Removes the environments from the active profile even if some vaults still need to be revoked by someone else",
 	}
 
 	EnvK8sFlag = utils.FlagDef{
 		Name:  "env-k8s",
 		Usage: "Shares vault access with the accessible k8s cluster",
 	}
+
+	envSecretBindingFlag = utils.FlagDef{
+		Name:  "binding",
+		Usage: "Secret binding to manage (defaults to the secret binding of the self environment)",
+	}
+
+	envRotateCheckpointFlag = utils.FlagDef{
+		Name:  "checkpoint",
+		Usage: "File to record the progress of the rotation in (defaults to env-rotation.yaml in the app data directory)",
+	}
+
+	envBackupThresholdFlag = utils.FlagDef{
+		Name:  "threshold",
+		Usage: "Number of recovery shares required to reconstruct the secret key",
+	}
+
+	envRecoverMnemonicFlag = utils.FlagDef{
+		Name:  "mnemonic",
+		Usage: "Mnemonic to recover the secret key from (prompted for if neither a mnemonic nor shares are given)",
+	}
+
+	envRecoverShareFlag = utils.FlagDef{
+		Name:  "share",
+		Usage: "Recovery share to reconstruct the secret key from (repeat for each share)",
+	}
+
+	envProviderFlag = utils.FlagDef{
+		Name:  "provider",
+		Usage: "Provider that binds the secret key of the environment (e.g. password, keyring, ssh, pgp)",
+	}
+
+	envProviderInputFlag = utils.FlagDef{
+		Name:  "input",
+		Usage: "Input for the provider plugin as key=value (repeat for each input)",
+	}
 )
@@ -31,6 +31,19 @@ func envDeleteCommand() *cobra.Command {
 				if err != nil {
 					utils.ExitOnError(err)
 				}
+				publicKeyStrings, _ := cmd.Flags().GetStringSlice(EnvPublicKeysFlag.Name)
+				for _, publicKeyStr := range publicKeyStrings {
+					publicKey, err := resolvePublicKey(profile, publicKeyStr)
+					if err != nil {
+						utils.ExitOnError(err)
+					}
+					if publicKeyStr, err = publicKey.String(); err != nil {
+						utils.ExitOnError(err)
+					}
+					if env, _ := profile.GetEnv(publicKeyStr); env != nil {
+						envs = append(envs, env)
+					}
+				}
 				if envs != nil {
 					for _, env := range envs {
 						ShowEnv(*env, false, false)
@@ -42,7 +55,7 @@ func envDeleteCommand() *cobra.Command {
 					}
 					if confirm {
 						for _, env := range envs {
-							if profile.DeleteEnv(env.PublicKey); err != nil {
+							if err = profile.DeleteEnv(env.PublicKey); err != nil {
 								utils.ExitOnError(err)
 							}
 							fmt.Printf("Environment %s deleted successfully\n", env.Name)
@@ -20,6 +20,12 @@ func EnvCommand() *cobra.Command {
 		envCmd.AddCommand(envSetSelfCommand())
 		envCmd.AddCommand(envShowCommand())
 		envCmd.AddCommand(envAddCommand())
+		envCmd.AddCommand(envOffboardCommand())
+		envCmd.AddCommand(envBindingCommand())
+		envCmd.AddCommand(envRebindCommand())
+		envCmd.AddCommand(envRotateCommand())
+		envCmd.AddCommand(envBackupCommand())
+		envCmd.AddCommand(envRecoverCommand())
 	}
 	return envCmd
 }
@@ -3,6 +3,7 @@ package cmdenv
 import (
 	"fmt"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"slv.sh/slv/internal/cli/commands/utils"
 	"slv.sh/slv/internal/core/environments"
@@ -36,6 +37,9 @@ func envListCommand() *cobra.Command {
 				}
 				for i, env := range envs {
 					ShowEnv(*env, showEnvDef, false)
+					if err = profile.VerifyEnv(env); err != nil {
+						fmt.Println(color.RedString("Not trusted: " + err.Error()))
+					}
 					if i < len(envs)-1 {
 						fmt.Println()
 					}
@@ -2,6 +2,7 @@ package cmdenv
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -45,10 +46,11 @@ func envNewServiceCommand() *cobra.Command {
 		envNewServiceCmd.MarkPersistentFlagRequired(envNameFlag.Name)
 		envNewServiceCmd.AddCommand(envNewDirectServiceCommand())
 		for _, envProviderId := range envproviders.ListIds() {
-			if envProviderId != envproviders.PasswordProviderId {
+			if envProviderId != envproviders.PasswordProviderId && envproviders.IsBindingRequired(envProviderId) {
 				envNewServiceCmd.AddCommand(getEnvProviderCommand(envProviderId))
 			}
 		}
+		envNewServiceCmd.AddCommand(getEnvProviderCommand(pluginProviderCommandName))
 	}
 	return envNewServiceCmd
 }
@@ -60,6 +62,7 @@ func envNewDirectServiceCommand() *cobra.Command {
 			Aliases: []string{"self-managed", "unmanaged"},
 			Short:   "Creates a new service environment and returns the secret key as plaintext (self-managed)",
 			Run: func(cmd *cobra.Command, args []string) {
+				checkCreateEnv()
 				name, _ := cmd.Flags().GetString(envNameFlag.Name)
 				email, _ := cmd.Flags().GetString(envEmailFlag.Name)
 				tags, err := cmd.Flags().GetStringSlice(envTagsFlag.Name)
@@ -95,6 +98,7 @@ func envNewDirectServiceCommand() *cobra.Command {
 						utils.ExitOnError(fmt.Errorf("failed to add the environment to profile (%s): %w", profile.Name(), err))
 					}
 					fmt.Printf("Successfully added the environment to profile (%s)\n", color.GreenString(profile.Name()))
+					signProfileEnv(profile, env)
 				}
 			},
 		}
@@ -109,6 +113,7 @@ func envNewUserCommand() *cobra.Command {
 			Aliases: []string{"user", "usr", "u"},
 			Short:   "Register as a new user environment",
 			Run: func(cmd *cobra.Command, args []string) {
+				checkCreateEnv()
 				selfEnv := environments.GetSelf()
 				if selfEnv != nil {
 					ShowEnv(*selfEnv, true, true)
@@ -140,15 +145,28 @@ func envNewUserCommand() *cobra.Command {
 				if err != nil {
 					utils.ExitOnError(err)
 				}
+				providerId, _ := cmd.Flags().GetString(envProviderFlag.Name)
 				inputs := make(map[string]string)
-				password, err := input.NewPasswordFromUser(input.DefaultPasswordPolicy())
-				if err != nil {
-					utils.ExitOnError(err)
+				if providerId == envproviders.PasswordProviderId {
+					password, err := input.NewPasswordFromUser(input.DefaultPasswordPolicy())
+					if err != nil {
+						utils.ExitOnError(err)
+					}
+					inputs["password"] = string(password)
+				} else if cmd.Flags().Changed(envProviderInputFlag.Name) {
+					if inputs, err = cmd.Flags().GetStringToString(envProviderInputFlag.Name); err != nil {
+						utils.ExitOnError(err)
+					}
+				} else {
+					for _, arg := range envproviders.GetArgs(providerId) {
+						if value, _ := cmd.Flags().GetString(arg.Id()); value != "" {
+							inputs[arg.Id()] = value
+						}
+					}
 				}
-				inputs["password"] = string(password)
 				var env *environments.Environment
 				pq, _ := cmd.Flags().GetBool(utils.QuantumSafeFlag.Name)
-				env, err = envproviders.NewEnv(envproviders.PasswordProviderId, envName, environments.USER, inputs, pq)
+				env, err = envproviders.NewEnv(providerId, envName, environments.USER, inputs, pq)
 				if err != nil {
 					utils.ExitOnError(err)
 				}
@@ -164,6 +182,7 @@ func envNewUserCommand() *cobra.Command {
 						utils.ExitOnError(fmt.Errorf("failed to add the environment to profile (%s): %w", profile.Name(), err))
 					}
 					fmt.Printf("Successfully added the environment to profile (%s)\n", color.GreenString(profile.Name()))
+					signProfileEnv(profile, env)
 				}
 				fmt.Println(color.GreenString("Successfully registered as self environment"))
 				if secretBinding != "" {
@@ -177,7 +196,30 @@ func envNewUserCommand() *cobra.Command {
 		envNewUserCmd.Flags().StringP(envEmailFlag.Name, envEmailFlag.Shorthand, "", envEmailFlag.Usage)
 		envNewUserCmd.Flags().StringSliceP(envTagsFlag.Name, envTagsFlag.Shorthand, []string{}, envTagsFlag.Usage)
 		envNewUserCmd.Flags().BoolP(envAddFlag.Name, envAddFlag.Shorthand, false, envAddFlag.Usage)
+		envNewUserCmd.Flags().String(envProviderFlag.Name, envproviders.PasswordProviderId, envProviderFlag.Usage)
+		if err := envNewUserCmd.RegisterFlagCompletionFunc(envProviderFlag.Name, envProviderCompletion); err != nil {
+			utils.ExitOnError(err)
+		}
+		envProviderIds := envproviders.ListIds()
+		sort.Strings(envProviderIds)
+		for _, envProviderId := range envProviderIds {
+			if envProviderId == envproviders.PasswordProviderId {
+				continue
+			}
+			for _, arg := range envproviders.GetArgs(envProviderId) {
+				if envNewUserCmd.Flags().Lookup(arg.Id()) == nil {
+					envNewUserCmd.Flags().String(arg.Id(), "", arg.Description())
+				}
+			}
+		}
+		envNewUserCmd.Flags().StringToString(envProviderInputFlag.Name, nil, envProviderInputFlag.Usage)
 		envNewUserCmd.MarkFlagRequired(envNameFlag.Name)
 	}
 	return envNewUserCmd
 }
+
+func envProviderCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	providerIds := envproviders.ListIds()
+	sort.Strings(providerIds)
+	return providerIds, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,203 @@
+package cmdenv
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/audit"
+	"slv.sh/slv/internal/core/crypto"
+	"slv.sh/slv/internal/core/environments"
+	"slv.sh/slv/internal/core/input"
+	"slv.sh/slv/internal/core/profiles"
+	"slv.sh/slv/internal/core/session"
+	"slv.sh/slv/internal/helpers"
+)
+
+func envOffboardCommand() *cobra.Command {
+	if envOffboardCmd == nil {
+		envOffboardCmd = &cobra.Command{
+			Use:   "offboard <pubkey|fingerprint|search>...",
+			Short: "Revokes an environment's access to all vaults and removes it from the active profile",
+			Long: `Scans the given directories and the vault directories registered with the active profile
+for vaults shared with the environment. Access is revoked (rotating the vault key) for every vault that the
+current session can unlock. Vaults that cannot be unlocked are listed so that someone with access can act on them.
+Finally, the environment is removed from the active profile. While vaults remain to be revoked, the environment is
+kept in the profile so that it can still be found to finish the revocation, unless --force is given.`,
+			Args: cobra.MinimumNArgs(1),
+			Run: func(cmd *cobra.Command, args []string) {
+				profile, err := profiles.GetActiveProfile()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				envs, publicKeys, err := resolveOffboardEnvs(profile, args)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				dryRun, _ := cmd.Flags().GetBool(envOffboardDryRunFlag.Name)
+				for _, env := range envs {
+					ShowEnv(*env, false, false)
+					fmt.Println()
+				}
+				if !dryRun {
+					confirm, err := input.GetConfirmation("Are you sure you wish to offboard the above environment(s) [yes/no]: ", "yes")
+					if err != nil {
+						utils.ExitOnError(err)
+					}
+					if !confirm {
+						utils.SafeExit()
+					}
+				}
+				dirs, _ := cmd.Flags().GetStringSlice(envOffboardDirFlag.Name)
+				profileDirs, err := profile.GetVaultDirs()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				dirs = append(dirs, profileDirs...)
+				if len(dirs) == 0 {
+					dirs = []string{"."}
+				}
+				vaultFiles, err := helpers.FindVaultsSharedWith(dirs, publicKeys)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				var results []*helpers.VaultAccessResult
+				if len(vaultFiles) > 0 {
+					secretKeys, err := session.GetSecretKeys()
+					if err != nil {
+						utils.ExitOnError(err)
+					}
+					pq, _ := cmd.Flags().GetBool(utils.QuantumSafeFlag.Name)
+					if !dryRun {
+						var revokedEnvs []string
+						for _, env := range envs {
+							revokedEnvs = append(revokedEnvs, env.PublicKey)
+						}
+						for _, result := range helpers.RevokeVaultAccess("", vaultFiles, secretKeys, publicKeys, pq, true) {
+							if result.Changed && result.Err == nil {
+								utils.AuditLog(&audit.Entry{Action: audit.Revoke, Vault: result.VaultFile, Envs: revokedEnvs})
+							}
+						}
+					}
+					results = helpers.RevokeVaultAccess("", vaultFiles, secretKeys, publicKeys, pq, dryRun)
+				}
+				pending := showOffboardResults(results, dryRun)
+				if dryRun {
+					utils.SafeExit()
+				}
+				if force, _ := cmd.Flags().GetBool(envOffboardForceFlag.Name); pending > 0 && !force {
+					fmt.Println(color.YellowString("The environment(s) are kept in profile %s until the above vaults are revoked; run 'slv env offboard' again once they are, or use --%s to remove them now",
+						profile.Name(), envOffboardForceFlag.Name))
+					utils.ErroredExit()
+				}
+				if !profile.IsPushSupported() {
+					fmt.Println(color.YellowString("Profile %s does not support deleting environments; remove them from the profile remote manually", profile.Name()))
+				} else {
+					for _, env := range envs {
+						if profileEnv, err := profile.GetEnv(env.PublicKey); err != nil || profileEnv == nil {
+							continue
+						}
+						if err = profile.DeleteEnv(env.PublicKey); err != nil {
+							utils.ExitOnError(err)
+						}
+						fmt.Printf("Environment %s removed from profile %s\n", color.GreenString(env.Name), profile.Name())
+					}
+				}
+				if pending > 0 {
+					utils.ErroredExit()
+				}
+				utils.SafeExit()
+			},
+		}
+		envOffboardCmd.Flags().StringSliceP(envOffboardDirFlag.Name, envOffboardDirFlag.Shorthand, []string{}, envOffboardDirFlag.Usage)
+		envOffboardCmd.Flags().BoolP(utils.QuantumSafeFlag.Name, utils.QuantumSafeFlag.Shorthand, false, utils.QuantumSafeFlag.Usage+" (used for the rotated vault keys)")
+		envOffboardCmd.Flags().Bool(envOffboardDryRunFlag.Name, false, envOffboardDryRunFlag.Usage)
+		envOffboardCmd.Flags().Bool(envOffboardForceFlag.Name, false, envOffboardForceFlag.Usage)
+		envOffboardCmd.ValidArgsFunction = EnvSearchCompletion
+	}
+	return envOffboardCmd
+}
+
+func resolveOffboardEnvs(profile *profiles.Profile, args []string) (envs []*environments.Environment, publicKeys []*crypto.PublicKey, err error) {
+	var queries []string
+	for _, arg := range args {
+		if publicKey, err := crypto.PublicKeyFromString(arg); err == nil {
+			env, err := profile.GetEnv(arg)
+			if err != nil {
+				return nil, nil, err
+			}
+			if env == nil {
+				env = &environments.Environment{PublicKey: arg}
+			}
+			envs = append(envs, env)
+			publicKeys = append(publicKeys, publicKey)
+		} else if _, err := crypto.ParseFingerprint(arg); err == nil {
+			env, err := profile.GetEnvByFingerprint(arg)
+			if err != nil {
+				return nil, nil, err
+			}
+			if env == nil {
+				return nil, nil, fmt.Errorf("no environment found in profile %s for the fingerprint %s", profile.Name(), arg)
+			}
+			publicKey, err := env.GetPublicKey()
+			if err != nil {
+				return nil, nil, err
+			}
+			envs = append(envs, env)
+			publicKeys = append(publicKeys, publicKey)
+		} else {
+			queries = append(queries, arg)
+		}
+	}
+	if len(queries) > 0 {
+		matches, err := profile.SearchEnvs(queries)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, env := range matches {
+			publicKey, err := env.GetPublicKey()
+			if err != nil {
+				return nil, nil, err
+			}
+			envs = append(envs, env)
+			publicKeys = append(publicKeys, publicKey)
+		}
+	}
+	if len(envs) == 0 {
+		return nil, nil, fmt.Errorf("no matching environments found")
+	}
+	return envs, publicKeys, nil
+}
+
+func showOffboardResults(results []*helpers.VaultAccessResult, dryRun bool) (pending int) {
+	if len(results) == 0 {
+		fmt.Println("No vaults found with access for the given environment(s)")
+		return 0
+	}
+	var pendingResults []*helpers.VaultAccessResult
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, result := range results {
+		switch {
+		case result.Err != nil:
+			pendingResults = append(pendingResults, result)
+		case dryRun:
+			fmt.Fprintln(w, result.VaultFile+"\t", color.GreenString("will be revoked"))
+		default:
+			fmt.Fprintln(w, result.VaultFile+"\t", color.GreenString("revoked"))
+		}
+	}
+	w.Flush()
+	if len(pendingResults) > 0 {
+		fmt.Println()
+		fmt.Println(color.YellowString("The following vaults need to be revoked by someone with access:"))
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, result := range pendingResults {
+			fmt.Fprintln(w, result.VaultFile+"\t", color.RedString(result.Err.Error()))
+		}
+		w.Flush()
+	}
+	return len(pendingResults)
+}
@@ -0,0 +1,50 @@
+package cmdenv
+
+import (
+	"sort"
+
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/environments/envproviders"
+)
+
+func envRebindCommand() *cobra.Command {
+	if envRebindCmd == nil {
+		envRebindCmd = &cobra.Command{
+			Use:     "rebind",
+			Aliases: []string{"re-bind"},
+			Short:   "Binds the secret key of an environment with a new provider or new inputs",
+			Long: `Unbinds the environment secret key with its current secret binding and binds the same secret key with the
+given provider, replacing all existing bindings. Use it to change the password of an environment, to move an
+environment from a password to a KMS, or to move to a new KMS key.
+
+The public key of the environment does not change, so vaults need not be shared again. The self environment,
+the environments of the active profile that use the secret binding and the locally cached passwords are updated.`,
+			Run: func(cmd *cobra.Command, args []string) {
+				cmd.Help()
+			},
+		}
+		envRebindCmd.PersistentFlags().String(envSecretBindingFlag.Name, "", envSecretBindingFlag.Usage)
+		providerIds := envproviders.ListIds()
+		sort.Strings(providerIds)
+		for _, providerId := range append(providerIds, pluginProviderCommandName) {
+			envRebindCmd.AddCommand(getEnvRebindProviderCommand(providerId))
+		}
+	}
+	return envRebindCmd
+}
+
+func getEnvRebindProviderCommand(providerId string) *cobra.Command {
+	return newProviderCommand(providerId, "Rebinds the environment secret key with ", func(cmd *cobra.Command, providerId string) {
+		secretBinding, selfEnv := getSecretBindingToManage(cmd)
+		if selfEnv == nil && !envproviders.IsBindingRequired(providerId) {
+			utils.ExitOnErrorWithMessage("the " + providerId + " provider can only bind the secret key of the self environment")
+		}
+		newSecretBinding, err := envproviders.RebindSecretKey(secretBinding, providerId, getProviderInputs(cmd, providerId))
+		if err != nil {
+			utils.ExitOnError(err)
+		}
+		saveSecretBinding(secretBinding, newSecretBinding, selfEnv)
+		utils.SafeExit()
+	})
+}
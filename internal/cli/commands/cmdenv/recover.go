@@ -0,0 +1,152 @@
+package cmdenv
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/crypto"
+	"slv.sh/slv/internal/core/environments"
+	"slv.sh/slv/internal/core/environments/envproviders"
+	"slv.sh/slv/internal/core/input"
+	"slv.sh/slv/internal/core/profiles"
+	"slv.sh/slv/internal/core/session"
+)
+
+func envRecoverCommand() *cobra.Command {
+	if envRecoverCmd == nil {
+		envRecoverCmd = &cobra.Command{
+			Use:   "recover",
+			Short: "Recovers the secret key of an environment from a backup and binds it with a provider",
+			Long: `Reconstructs the secret key of an environment from a mnemonic or from recovery shares created with
+'slv env backup', and binds it with the given provider. Recovery shares are given either as opened with
+'slv env backup open-share', or as they were created if the environment of the current session holds them.
+
+The public key of the environment does not change. The environment is looked up in the self environment and in the
+active profile, or given with --env-def. A recovered user environment is set as the self environment.`,
+			Run: func(cmd *cobra.Command, args []string) {
+				cmd.Help()
+			},
+		}
+		envRecoverCmd.PersistentFlags().String(envRecoverMnemonicFlag.Name, "", envRecoverMnemonicFlag.Usage)
+		envRecoverCmd.PersistentFlags().StringArray(envRecoverShareFlag.Name, []string{}, envRecoverShareFlag.Usage)
+		envRecoverCmd.PersistentFlags().StringP(envDefFlag.Name, envDefFlag.Shorthand, "", envDefFlag.Usage)
+		envRecoverCmd.MarkFlagsMutuallyExclusive(envRecoverMnemonicFlag.Name, envRecoverShareFlag.Name)
+		providerIds := envproviders.ListIds()
+		sort.Strings(providerIds)
+		for _, providerId := range append(providerIds, pluginProviderCommandName) {
+			envRecoverCmd.AddCommand(getEnvRecoverProviderCommand(providerId))
+		}
+	}
+	return envRecoverCmd
+}
+
+func getRecoveredSecretKey(cmd *cobra.Command) *crypto.SecretKey {
+	recoveryShares, _ := cmd.Flags().GetStringArray(envRecoverShareFlag.Name)
+	if len(recoveryShares) > 0 {
+		var sessionSecretKey *crypto.SecretKey
+		if sess, err := session.GetSession(); err == nil {
+			sessionSecretKey = sess.SecretKey()
+		}
+		secretKey, err := environments.SecretKeyFromRecoveryShares(recoveryShares, sessionSecretKey)
+		if err != nil {
+			utils.ExitOnError(err)
+		}
+		return secretKey
+	}
+	mnemonic, _ := cmd.Flags().GetString(envRecoverMnemonicFlag.Name)
+	if mnemonic == "" {
+		mnemonicBytes, err := input.GetHiddenInput("Enter the mnemonic: ")
+		if err != nil {
+			utils.ExitOnError(err)
+		}
+		mnemonic = string(mnemonicBytes)
+	}
+	secretKey, err := environments.SecretKeyFromMnemonic(mnemonic)
+	if err != nil {
+		utils.ExitOnError(err)
+	}
+	return secretKey
+}
+
+// getRecoveredEnv returns the environment of the recovered secret key, given with --env-def or found as the self
+// environment or in the active profile. It returns nil if the environment is not found.
+func getRecoveredEnv(cmd *cobra.Command, secretKey *crypto.SecretKey) *environments.Environment {
+	var publicKeys []string
+	for _, pq := range []bool{false, true} {
+		publicKey, err := secretKey.PublicKey(pq)
+		if err != nil {
+			utils.ExitOnError(err)
+		}
+		publicKeyStr, err := publicKey.String()
+		if err != nil {
+			utils.ExitOnError(err)
+		}
+		publicKeys = append(publicKeys, publicKeyStr)
+	}
+	isRecovered := func(env *environments.Environment) bool {
+		return env != nil && (env.PublicKey == publicKeys[0] || env.PublicKey == publicKeys[1])
+	}
+	if envDef, _ := cmd.Flags().GetString(envDefFlag.Name); envDef != "" {
+		env, err := environments.FromDefStr(envDef)
+		if err != nil {
+			utils.ExitOnError(err)
+		}
+		if !isRecovered(env) {
+			utils.ExitOnErrorWithMessage("the recovered secret key does not belong to the given environment")
+		}
+		return env
+	}
+	if selfEnv := environments.GetSelf(); isRecovered(selfEnv) {
+		return selfEnv
+	}
+	if profile, err := profiles.GetActiveProfile(); err == nil {
+		for _, publicKey := range publicKeys {
+			if env, err := profile.GetEnv(publicKey); err == nil && env != nil {
+				return env
+			}
+		}
+	}
+	return nil
+}
+
+func getEnvRecoverProviderCommand(providerId string) *cobra.Command {
+	return newProviderCommand(providerId, "Recovers the environment secret key and binds it with ", func(cmd *cobra.Command, providerId string) {
+		secretKey := getRecoveredSecretKey(cmd)
+		env := getRecoveredEnv(cmd, secretKey)
+		if !envproviders.IsBindingRequired(providerId) && (env == nil || env.EnvType != environments.USER) {
+			utils.ExitOnErrorWithMessage("the " + providerId + " provider can only bind the secret key of a user environment")
+		}
+		secretKeyBytes, err := secretKey.Bytes()
+		if err != nil {
+			utils.ExitOnError(err)
+		}
+		secretBinding, err := envproviders.BindSecretKey(secretKeyBytes, providerId, getProviderInputs(cmd, providerId))
+		if err != nil {
+			utils.ExitOnError(err)
+		}
+		fmt.Println(color.GreenString("Recovered the secret key of the environment"))
+		if env == nil {
+			publicKey, err := secretKey.PublicKey(false)
+			if err != nil {
+				utils.ExitOnError(err)
+			}
+			publicKeyStr, err := publicKey.String()
+			if err != nil {
+				utils.ExitOnError(err)
+			}
+			fmt.Println("Public Key:", color.CyanString(publicKeyStr))
+			fmt.Println("Secret Binding:", secretBinding)
+			fmt.Println(color.YellowString("The environment was not found: use --" + envDefFlag.Name + " to update it, or replace its secret binding with the one above wherever it is used."))
+			utils.SafeExit()
+		}
+		var selfEnv *environments.Environment
+		if env.EnvType == environments.USER {
+			selfEnv = env
+		}
+		saveSecretBinding(env.SecretBinding, secretBinding, selfEnv)
+		utils.SafeExit()
+	})
+}
@@ -0,0 +1,272 @@
+package cmdenv
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/audit"
+	"slv.sh/slv/internal/core/commons"
+	"slv.sh/slv/internal/core/config"
+	"slv.sh/slv/internal/core/crypto"
+	"slv.sh/slv/internal/core/environments"
+	"slv.sh/slv/internal/core/environments/envproviders"
+	"slv.sh/slv/internal/core/input"
+	"slv.sh/slv/internal/core/profiles"
+	"slv.sh/slv/internal/core/session"
+	"slv.sh/slv/internal/helpers"
+)
+
+const envRotationCheckpointFileName = "env-rotation.yaml"
+
+// envRotationCheckpoint records an environment rotation in progress, so that it can be resumed if interrupted.
+// The secret key of the new environment is only held through its secret binding.
+type envRotationCheckpoint struct {
+	OldPublicKey string   `yaml:"oldPublicKey"`
+	NewEnvDef    string   `yaml:"newEnvDef"`
+	Dirs         []string `yaml:"dirs"`
+	Rotated      []string `yaml:"rotated,omitempty"`
+}
+
+// writeEnvRotationCheckpoint writes the checkpoint readable by the owner alone, as the secret binding of the new
+// environment may hold its secret key wrapped with a password or a key outside of any KMS.
+func writeEnvRotationCheckpoint(checkpointPath string, checkpoint *envRotationCheckpoint) error {
+	data, err := yaml.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	return commons.WriteFileAtomic(checkpointPath, data, 0600)
+}
+
+func getEnvRotationCheckpointPath(cmd *cobra.Command) string {
+	if checkpointPath, _ := cmd.Flags().GetString(envRotateCheckpointFlag.Name); checkpointPath != "" {
+		return checkpointPath
+	}
+	return filepath.Join(config.GetAppDataDir(), envRotationCheckpointFileName)
+}
+
+func envRotateCommand() *cobra.Command {
+	if envRotateCmd == nil {
+		envRotateCmd = &cobra.Command{
+			Use:   "rotate",
+			Short: "Replaces the secret key of an environment and moves its vault access to the new key",
+			Long: `Creates a new key pair for the environment of the current session, bound with the given provider (usually the
+provider it is bound with today). Every vault found in the given directories and in the vault directories registered
+with the active profile that the old key can unlock is shared with the new key, after which the old key is revoked
+(rotating the vault key). Finally, the environment is replaced in the active profile and as the self environment.
+
+Progress is recorded in a checkpoint file. If the rotation is interrupted or some vaults fail, run 'slv env rotate'
+without a provider to resume it.`,
+			Args: cobra.NoArgs,
+			Run: func(cmd *cobra.Command, args []string) {
+				checkpointPath := getEnvRotationCheckpointPath(cmd)
+				if !commons.FileExists(checkpointPath) {
+					cmd.Help()
+					utils.SafeExit()
+				}
+				checkpoint := new(envRotationCheckpoint)
+				if err := commons.ReadFromYAML(checkpointPath, checkpoint); err != nil {
+					utils.ExitOnError(err)
+				}
+				sess, err := session.GetSession()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				if checkpoint.OldPublicKey != sess.PublicKeyEC() && checkpoint.OldPublicKey != sess.PublicKeyPQ() {
+					utils.ExitOnErrorWithMessage("the rotation in progress (" + checkpointPath + ") is of another environment than that of the current session")
+				}
+				oldEnv, _ := sess.Env()
+				if oldEnv == nil {
+					oldEnv = &environments.Environment{PublicKey: checkpoint.OldPublicKey}
+				}
+				fmt.Printf("Resuming the rotation of the environment %s (%d vaults rotated so far)\n",
+					color.CyanString(oldEnv.PublicKey), len(checkpoint.Rotated))
+				pq, _ := cmd.Flags().GetBool(utils.QuantumSafeFlag.Name)
+				rotateEnvVaults(checkpoint, checkpointPath, sess.SecretKey(), oldEnv, pq)
+			},
+		}
+		envRotateCmd.PersistentFlags().StringSliceP(envOffboardDirFlag.Name, envOffboardDirFlag.Shorthand, []string{}, envOffboardDirFlag.Usage)
+		envRotateCmd.PersistentFlags().BoolP(utils.QuantumSafeFlag.Name, utils.QuantumSafeFlag.Shorthand, false, utils.QuantumSafeFlag.Usage+" (used for the rotated vault keys)")
+		envRotateCmd.PersistentFlags().String(envRotateCheckpointFlag.Name, "", envRotateCheckpointFlag.Usage)
+		providerIds := envproviders.ListIds()
+		sort.Strings(providerIds)
+		for _, providerId := range providerIds {
+			// The keyring only binds the secret key on this host, which need not be the only one the environment is used on.
+			if envproviders.IsBindingRequired(providerId) {
+				envRotateCmd.AddCommand(getEnvRotateProviderCommand(providerId))
+			}
+		}
+		envRotateCmd.AddCommand(getEnvRotateProviderCommand(pluginProviderCommandName))
+	}
+	return envRotateCmd
+}
+
+func getEnvRotateProviderCommand(providerId string) *cobra.Command {
+	return newProviderCommand(providerId, "Rotates the environment secret key, binding the new one with ", func(cmd *cobra.Command, providerId string) {
+		checkpointPath := getEnvRotationCheckpointPath(cmd)
+		if commons.FileExists(checkpointPath) {
+			utils.ExitOnErrorWithMessage("an environment rotation is in progress: run 'slv env rotate' to resume it or remove " + checkpointPath)
+		}
+		sess, err := session.GetSession()
+		if err != nil {
+			utils.ExitOnError(err)
+		}
+		secretKey, err := session.GetSecretKey()
+		if err != nil {
+			utils.ExitOnError(err)
+		}
+		oldEnv, err := sess.Env()
+		if err != nil || oldEnv == nil {
+			utils.ExitOnErrorWithMessage("the environment of the current session is neither the self environment nor found in the active profile")
+		}
+		ShowEnv(*oldEnv, false, false)
+		fmt.Println()
+		confirm, err := input.GetConfirmation("Are you sure you wish to rotate the secret key of the above environment [yes/no]: ", "yes")
+		if err != nil {
+			utils.ExitOnError(err)
+		}
+		if !confirm {
+			utils.SafeExit()
+		}
+		newEnv, err := envproviders.NewEnv(providerId, oldEnv.Name, oldEnv.EnvType,
+			getProviderInputs(cmd, providerId), oldEnv.PublicKey == sess.PublicKeyPQ())
+		if err != nil {
+			utils.ExitOnError(err)
+		}
+		newEnv.SetEmail(oldEnv.Email)
+		newEnv.AddTags(oldEnv.Tags...)
+		newEnvDef, err := newEnv.ToDefStr(false)
+		if err != nil {
+			utils.ExitOnError(err)
+		}
+		dirs, _ := cmd.Flags().GetStringSlice(envOffboardDirFlag.Name)
+		if profile, err := profiles.GetActiveProfile(); err == nil {
+			profileDirs, err := profile.GetVaultDirs()
+			if err != nil {
+				utils.ExitOnError(err)
+			}
+			dirs = append(dirs, profileDirs...)
+		}
+		if len(dirs) == 0 {
+			dirs = []string{"."}
+		}
+		checkpoint := &envRotationCheckpoint{
+			OldPublicKey: oldEnv.PublicKey,
+			NewEnvDef:    newEnvDef,
+			Dirs:         dirs,
+		}
+		if err = writeEnvRotationCheckpoint(checkpointPath, checkpoint); err != nil {
+			utils.ExitOnError(err)
+		}
+		fmt.Println("New Public Key:", color.CyanString(newEnv.PublicKey))
+		pq, _ := cmd.Flags().GetBool(utils.QuantumSafeFlag.Name)
+		rotateEnvVaults(checkpoint, checkpointPath, secretKey, oldEnv, pq)
+	})
+}
+
+// rotateEnvVaults moves the access of the vaults from the old key to the new one, recording progress in the
+// checkpoint, and replaces the environment once every vault has been rotated.
+func rotateEnvVaults(checkpoint *envRotationCheckpoint, checkpointPath string, secretKey *crypto.SecretKey,
+	oldEnv *environments.Environment, quantumSafe bool) {
+	newEnv, err := environments.FromDefStr(checkpoint.NewEnvDef)
+	if err != nil {
+		utils.ExitOnError(err)
+	}
+	newPublicKey, err := newEnv.GetPublicKey()
+	if err != nil {
+		utils.ExitOnError(err)
+	}
+	var oldPublicKeys []*crypto.PublicKey
+	for _, pq := range []bool{false, true} {
+		oldPublicKey, err := secretKey.PublicKey(pq)
+		if err != nil {
+			utils.ExitOnError(err)
+		}
+		oldPublicKeys = append(oldPublicKeys, oldPublicKey)
+	}
+	vaultFiles, err := helpers.FindVaultsSharedWith(checkpoint.Dirs, oldPublicKeys)
+	if err != nil {
+		utils.ExitOnError(err)
+	}
+	var pendingResults []*helpers.VaultAccessResult
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, vaultFile := range vaultFiles {
+		utils.AuditLog(&audit.Entry{Action: audit.Grant, Vault: vaultFile, Envs: []string{newEnv.PublicKey}})
+		utils.AuditLog(&audit.Entry{Action: audit.Revoke, Vault: vaultFile, Envs: []string{oldEnv.PublicKey}})
+		result := helpers.ReplaceVaultAccess(vaultFile, secretKey, oldPublicKeys, newPublicKey, quantumSafe)
+		if result.Err != nil {
+			pendingResults = append(pendingResults, result)
+			continue
+		}
+		if !slices.Contains(checkpoint.Rotated, vaultFile) {
+			checkpoint.Rotated = append(checkpoint.Rotated, vaultFile)
+		}
+		if err = writeEnvRotationCheckpoint(checkpointPath, checkpoint); err != nil {
+			utils.ExitOnError(err)
+		}
+		fmt.Fprintln(w, vaultFile+"\t", color.GreenString("rotated"))
+	}
+	w.Flush()
+	if len(vaultFiles) == 0 {
+		fmt.Println("No vaults found with access for the environment")
+	}
+	if len(pendingResults) > 0 {
+		fmt.Println()
+		fmt.Println(color.YellowString("The following vaults could not be rotated:"))
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, result := range pendingResults {
+			fmt.Fprintln(w, result.VaultFile+"\t", color.RedString(result.Err.Error()))
+		}
+		w.Flush()
+		fmt.Println(color.YellowString("Please resolve the above and run 'slv env rotate' to resume the rotation."))
+		utils.ErroredExit()
+	}
+	replaceRotatedEnv(oldEnv, newEnv)
+	fmt.Println(color.GreenString("Successfully rotated the secret key of the environment"))
+	if err = os.Remove(checkpointPath); err != nil && !os.IsNotExist(err) {
+		utils.ExitOnErrorWithMessage("Failed to remove the rotation checkpoint " + checkpointPath +
+			", which holds the secret binding of the new environment; please remove it manually: " + err.Error())
+	}
+	utils.SafeExit()
+}
+
+func replaceRotatedEnv(oldEnv, newEnv *environments.Environment) {
+	if profile, err := profiles.GetActiveProfile(); err == nil {
+		if root, _ := profile.GetRoot(); root != nil && root.PublicKey == oldEnv.PublicKey {
+			fmt.Println(color.YellowString("The environment is the root of profile %s; please update the root in the profile remote manually", profile.Name()))
+		}
+		if profileEnv, _ := profile.GetEnv(oldEnv.PublicKey); profileEnv != nil {
+			if !profile.IsPushSupported() {
+				fmt.Println(color.YellowString("Profile %s does not support updating environments; replace the environment in the profile remote manually", profile.Name()))
+			} else {
+				if err = profile.PutEnv(newEnv); err != nil {
+					utils.ExitOnError(err)
+				}
+				if err = profile.DeleteEnv(oldEnv.PublicKey); err != nil {
+					utils.ExitOnError(err)
+				}
+				fmt.Printf("Environment %s replaced in profile %s\n", color.GreenString(newEnv.Name), profile.Name())
+				signProfileEnv(profile, newEnv)
+			}
+		}
+	}
+	if selfEnv := environments.GetSelf(); selfEnv != nil && selfEnv.PublicKey == oldEnv.PublicKey {
+		if err := newEnv.SetAsSelf(); err != nil {
+			utils.ExitOnError(err)
+		}
+		if err := envproviders.ForgetSecretBinding(selfEnv.SecretBinding, newEnv.SecretBinding); err != nil {
+			fmt.Println(color.YellowString("Failed to remove the locally cached secrets of the previous secret binding: " + err.Error()))
+		}
+		fmt.Println(color.GreenString("Updated the self environment"))
+		return
+	}
+	ShowEnv(*newEnv, true, false)
+	fmt.Println(color.YellowString("Please replace the previous secret binding with the one above wherever it is used."))
+}
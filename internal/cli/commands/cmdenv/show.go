@@ -17,10 +17,16 @@ import (
 func ShowEnv(env environments.Environment, includeEDS, omitBindingFromEDS bool) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.TabIndent)
 	fmt.Fprintln(w, "Public Key:\t", env.PublicKey)
+	if fingerprint := env.Fingerprint(); fingerprint != "" {
+		fmt.Fprintln(w, "Fingerprint:\t", fingerprint)
+	}
 	fmt.Fprintln(w, "Name:\t", env.Name)
 	fmt.Fprintln(w, "Email:\t", env.Email)
 	fmt.Fprintln(w, "Tags:\t", env.Tags)
 	fmt.Fprintln(w, "Type:\t", env.EnvType)
+	if env.SigningKey != "" {
+		fmt.Fprintln(w, "Signing Key:\t", env.SigningKey)
+	}
 	if env.SecretBinding != "" {
 		fmt.Fprintln(w, "Secret Binding:\t", env.SecretBinding)
 	}
@@ -87,7 +93,7 @@ func envShowSelfCommand() *cobra.Command {
 		envShowSelfCmd = &cobra.Command{
 			Use:     "self",
 			Aliases: []string{"me"},
-			Short:   "Shows the current user environment if registered in the host",
+			Short:   "Shows the current user environment if registered in the host, and the identities of the session",
 			Run: func(cmd *cobra.Command, args []string) {
 				env := environments.GetSelf()
 				if env == nil {
@@ -95,12 +101,41 @@ func envShowSelfCommand() *cobra.Command {
 				} else {
 					ShowEnv(*env, true, true)
 				}
+				showSessionIdentities()
 			},
 		}
 	}
 	return envShowSelfCmd
 }
 
+// showSessionIdentities lists the identities the session acts with, marking the primary one.
+func showSessionIdentities() {
+	sess, err := session.GetSession()
+	if err != nil {
+		fmt.Println(color.YellowString("\nFailed to load the identities of the session: " + err.Error()))
+		return
+	}
+	identities := sess.Identities()
+	if len(identities) == 0 {
+		return
+	}
+	fmt.Println("\nSession Identities:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  \tName\tFingerprint\tSource\tPublic Key")
+	for i, id := range identities {
+		marker := " "
+		if i == 0 {
+			marker = "*"
+		}
+		name := "-"
+		if env, _ := id.Env(); env != nil {
+			name = env.Name
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", marker, name, id.Fingerprint(), id.Source(), id.PublicKeyEC())
+	}
+	w.Flush()
+}
+
 func envShowK8sCommand() *cobra.Command {
 	if envShowK8sCmd == nil {
 		envShowK8sCmd = &cobra.Command{
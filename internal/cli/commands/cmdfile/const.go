@@ -0,0 +1,29 @@
+package cmdfile
+
+import (
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+)
+
+const (
+	encryptedFileExt = ".slv"
+)
+
+var (
+	fileCmd        *cobra.Command
+	fileEncryptCmd *cobra.Command
+	fileDecryptCmd *cobra.Command
+)
+
+var (
+	fileVaultFlag = utils.FlagDef{
+		Name:      "vault",
+		Shorthand: "v",
+		Usage:     "Path to the vault that holds the data keys of the encrypted files",
+	}
+
+	fileForceFlag = utils.FlagDef{
+		Name:  "force",
+		Usage: "Overwrites the output file if it exists already",
+	}
+)
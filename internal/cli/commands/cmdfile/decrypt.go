@@ -0,0 +1,74 @@
+package cmdfile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/audit"
+	"slv.sh/slv/internal/core/session"
+	"slv.sh/slv/internal/core/vaults"
+)
+
+func fileDecryptCommand() *cobra.Command {
+	if fileDecryptCmd == nil {
+		fileDecryptCmd = &cobra.Command{
+			Use:   "decrypt <input> [output]",
+			Short: "Decrypts a file with its data key from the vault",
+			Long: `Decrypts a file encrypted with 'slv file encrypt' using the data key recorded in the vault.
+The output defaults to the input path without the ` + encryptedFileExt + ` extension. Use - to read from stdin or write to stdout.
+Output written to stdout may be incomplete if the file turns out to be modified or truncated.`,
+			Args: cobra.RangeArgs(1, 2),
+			Run: func(cmd *cobra.Command, args []string) {
+				vaultFile := cmd.Flag(fileVaultFlag.Name).Value.String()
+				force, _ := cmd.Flags().GetBool(fileForceFlag.Name)
+				inputPath := args[0]
+				outputPath := "-"
+				if len(args) > 1 {
+					outputPath = args[1]
+				} else if inputPath != "-" {
+					if !strings.HasSuffix(inputPath, encryptedFileExt) {
+						utils.ExitOnErrorWithMessage("specify the output file for inputs without the " + encryptedFileExt + " extension")
+					}
+					outputPath = strings.TrimSuffix(inputPath, encryptedFileExt)
+				}
+				vault, err := vaults.Get(vaultFile)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				envSecretKeys, err := session.GetSecretKeys()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				if err = vault.Unlock(envSecretKeys...); err != nil {
+					utils.ExitOnError(err)
+				}
+				input, err := openInputFile(inputPath)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				defer input.Close()
+				utils.AuditLog(&audit.Entry{Action: audit.Decrypt, Vault: vaultFile, File: inputPath})
+				output, err := createOutputFile(outputPath, force)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				if _, err = vault.DecryptFile(output, input); err == nil {
+					err = output.commit()
+				}
+				if err != nil {
+					output.discard()
+					utils.ExitOnError(err)
+				}
+				if !output.isStdout() {
+					fmt.Printf("Decrypted %s to %s\n", inputPath, color.GreenString(outputPath))
+				}
+				utils.SafeExit()
+			},
+		}
+		fileDecryptCmd.Flags().Bool(fileForceFlag.Name, false, fileForceFlag.Usage)
+	}
+	return fileDecryptCmd
+}
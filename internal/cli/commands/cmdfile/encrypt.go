@@ -0,0 +1,68 @@
+package cmdfile
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/audit"
+	"slv.sh/slv/internal/core/session"
+	"slv.sh/slv/internal/core/vaults"
+	"slv.sh/slv/internal/helpers"
+)
+
+func fileEncryptCommand() *cobra.Command {
+	if fileEncryptCmd == nil {
+		fileEncryptCmd = &cobra.Command{
+			Use:   "encrypt <input> [output]",
+			Short: "Encrypts a file with a new data key sealed to the vault",
+			Long: `Encrypts the input file under a new data key and records the sealed data key in the vault.
+The output defaults to the input path with the ` + encryptedFileExt + ` extension. Use - to read from stdin or write to stdout.`,
+			Args: cobra.RangeArgs(1, 2),
+			Run: func(cmd *cobra.Command, args []string) {
+				vaultFile := cmd.Flag(fileVaultFlag.Name).Value.String()
+				force, _ := cmd.Flags().GetBool(fileForceFlag.Name)
+				inputPath := args[0]
+				outputPath := "-"
+				if len(args) > 1 {
+					outputPath = args[1]
+				} else if inputPath != "-" {
+					outputPath = inputPath + encryptedFileExt
+				}
+				vault, err := vaults.Get(vaultFile)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				envSecretKeys, _ := session.GetSecretKeys()
+				if _, err = helpers.SetVaultSigner(vault, envSecretKeys...); err != nil {
+					utils.ExitOnError(err)
+				}
+				input, err := openInputFile(inputPath)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				defer input.Close()
+				utils.AuditLog(&audit.Entry{Action: audit.Encrypt, Vault: vaultFile, File: inputPath})
+				output, err := createOutputFile(outputPath, force)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				fileId, err := vault.EncryptFile(output, input)
+				if err == nil {
+					err = output.commit()
+				}
+				if err != nil {
+					output.discard()
+					utils.ExitOnError(err)
+				}
+				if !output.isStdout() {
+					fmt.Printf("Encrypted %s to %s (file %s in vault %s)\n", inputPath, color.GreenString(outputPath), fileId, vaultFile)
+				}
+				utils.SafeExit()
+			},
+		}
+		fileEncryptCmd.Flags().Bool(fileForceFlag.Name, false, fileForceFlag.Usage)
+	}
+	return fileEncryptCmd
+}
@@ -0,0 +1,95 @@
+package cmdfile
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/commons"
+	"slv.sh/slv/internal/helpers"
+)
+
+func FileCommand() *cobra.Command {
+	if fileCmd == nil {
+		fileCmd = &cobra.Command{
+			Use:     "file",
+			Aliases: []string{"files", "blob"},
+			Short:   "Encrypt and decrypt large files with the keys of a vault",
+			Long: `Encrypts files that are too large or binary to be stored as vault items, such as keystores, database dumps and certificate bundles.
+Every file is encrypted in authenticated chunks under its own data key. The data key is sealed to the vault and recorded in it,
+so only the environments that have access to the vault can decrypt the file.`,
+			Run: func(cmd *cobra.Command, args []string) {
+				cmd.Help()
+			},
+		}
+		fileCmd.PersistentFlags().StringP(fileVaultFlag.Name, fileVaultFlag.Shorthand, "", fileVaultFlag.Usage)
+		fileCmd.MarkPersistentFlagRequired(fileVaultFlag.Name)
+		if err := fileCmd.RegisterFlagCompletionFunc(fileVaultFlag.Name, vaultFilePathCompletion); err != nil {
+			utils.ExitOnError(err)
+		}
+		fileCmd.AddCommand(fileEncryptCommand())
+		fileCmd.AddCommand(fileDecryptCommand())
+	}
+	return fileCmd
+}
+
+func vaultFilePathCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if vaultFiles, err := helpers.ListVaultFiles("", true); err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	} else {
+		return vaultFiles, cobra.ShellCompDirectiveDefault
+	}
+}
+
+func openInputFile(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return os.Stdin, nil
+	}
+	return os.Open(path)
+}
+
+// outputFile is written to a temporary file next to the output path, which replaces the output path only once
+// it is complete. A failure therefore never leaves partial output behind. "-" writes to stdout instead.
+type outputFile struct {
+	*os.File
+	path string
+}
+
+func createOutputFile(path string, force bool) (*outputFile, error) {
+	if path == "-" {
+		return &outputFile{File: os.Stdout}, nil
+	}
+	if !force && commons.FileExists(path) {
+		return nil, fmt.Errorf("output file %s exists already (use --%s to overwrite)", path, fileForceFlag.Name)
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	return &outputFile{File: file, path: path}, nil
+}
+
+func (out *outputFile) isStdout() bool {
+	return out.path == ""
+}
+
+func (out *outputFile) commit() error {
+	if out.isStdout() {
+		return nil
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(out.Name())
+		return err
+	}
+	return os.Rename(out.Name(), out.path)
+}
+
+func (out *outputFile) discard() {
+	if !out.isStdout() {
+		out.Close()
+		os.Remove(out.Name())
+	}
+}
@@ -0,0 +1,95 @@
+package cmdprofile
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/profiles"
+	"slv.sh/slv/internal/core/session"
+)
+
+func profileAdminCommand() *cobra.Command {
+	if profileAdminCmd == nil {
+		profileAdminCmd = &cobra.Command{
+			Use:     "admin",
+			Aliases: []string{"admins"},
+			Short:   "Lists the admins the root of the active profile has delegated to sign environments",
+			Run: func(cmd *cobra.Command, args []string) {
+				profile, err := profiles.GetActiveProfile()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				admins, err := profile.ListAdmins()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				if len(admins) == 0 {
+					fmt.Println("No admins in profile", color.GreenString(profile.Name()))
+				}
+				for _, admin := range admins {
+					fmt.Printf("%s %s\n", color.CyanString(admin.Name), admin.PublicKey)
+				}
+			},
+		}
+		profileAdminCmd.AddCommand(profileAdminAddCommand())
+		profileAdminCmd.AddCommand(profileAdminRemoveCommand())
+	}
+	return profileAdminCmd
+}
+
+func profileAdminAddCommand() *cobra.Command {
+	if profileAdminAddCmd == nil {
+		profileAdminAddCmd = &cobra.Command{
+			Use:   "add <public key or fingerprint>",
+			Short: "Delegates an environment of the active profile to sign environments, as the root",
+			Args:  cobra.ExactArgs(1),
+			Run: func(cmd *cobra.Command, args []string) {
+				profile, err := profiles.GetActiveProfile()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				env, err := getProfileEnv(profile, args[0])
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				secretKeys, err := session.GetSecretKeys()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				if err = profile.AddAdmin(secretKeys, env); err != nil {
+					utils.ExitOnError(err)
+				}
+				fmt.Println("Added admin:", color.GreenString(env.Name))
+			},
+		}
+	}
+	return profileAdminAddCmd
+}
+
+func profileAdminRemoveCommand() *cobra.Command {
+	if profileAdminRemoveCmd == nil {
+		profileAdminRemoveCmd = &cobra.Command{
+			Use:     "rm <public key or fingerprint>",
+			Aliases: []string{"remove", "delete", "del"},
+			Short:   "Withdraws the delegation of an admin of the active profile",
+			Args:    cobra.ExactArgs(1),
+			Run: func(cmd *cobra.Command, args []string) {
+				profile, err := profiles.GetActiveProfile()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				env, err := getProfileEnv(profile, args[0])
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				if err = profile.RemoveAdmin(env); err != nil {
+					utils.ExitOnError(err)
+				}
+				fmt.Println("Removed admin:", color.GreenString(env.Name))
+			},
+		}
+	}
+	return profileAdminRemoveCmd
+}
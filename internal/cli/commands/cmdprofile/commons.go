@@ -1,17 +1,32 @@
 package cmdprofile
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/environments"
+	"slv.sh/slv/internal/core/profiles"
 )
 
 var (
-	profileCmd          *cobra.Command
-	profileNewCmd       *cobra.Command
-	profileListCmd      *cobra.Command
-	profileSetActiveCmd *cobra.Command
-	profileDelCmd       *cobra.Command
-	profileSyncCmd      *cobra.Command
+	profileCmd             *cobra.Command
+	profileNewCmd          *cobra.Command
+	profileListCmd         *cobra.Command
+	profileSetActiveCmd    *cobra.Command
+	profileDelCmd          *cobra.Command
+	profileSyncCmd         *cobra.Command
+	profileDirsCmd         *cobra.Command
+	profileDirsAddCmd      *cobra.Command
+	profileDirsRemoveCmd   *cobra.Command
+	profileServeCmd        *cobra.Command
+	profileSignCmd         *cobra.Command
+	profileAdminCmd        *cobra.Command
+	profileAdminAddCmd     *cobra.Command
+	profileAdminRemoveCmd  *cobra.Command
+	profileSettingsCmd     *cobra.Command
+	profileSettingsShowCmd *cobra.Command
+	profileSettingsSetCmd  *cobra.Command
 )
 
 var (
@@ -30,4 +45,40 @@ var (
 		Name:  "sync-interval",
 		Usage: "Profile sync interval",
 	}
+
+	profileServeDirFlag = utils.FlagDef{
+		Name:  "dir",
+		Usage: "Directory holding the environments and settings manifests to serve",
+	}
+
+	profileServeHostFlag = utils.FlagDef{
+		Name:  "host",
+		Usage: "Address to listen on (listening beyond the loopback interface requires --auth-header)",
+	}
+
+	profileServePortFlag = utils.FlagDef{
+		Name:      "port",
+		Shorthand: "p",
+		Usage:     "Port to serve the profile on",
+	}
+
+	profileServeAuthHeaderFlag = utils.FlagDef{
+		Name:  "auth-header",
+		Usage: "The header clients must authenticate with. E.g. 'Authorization: Bearer <token>'",
+	}
 )
+
+// getProfileEnv returns the environment of the profile with the given public key or public key fingerprint.
+func getProfileEnv(profile *profiles.Profile, publicKeyOrFingerprint string) (*environments.Environment, error) {
+	env, err := profile.GetEnv(publicKeyOrFingerprint)
+	if err == nil && env == nil {
+		env, _ = profile.GetEnvByFingerprint(publicKeyOrFingerprint)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if env == nil {
+		return nil, fmt.Errorf("no environment found in profile %s for %s", profile.Name(), publicKeyOrFingerprint)
+	}
+	return env, nil
+}
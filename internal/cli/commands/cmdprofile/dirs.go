@@ -0,0 +1,86 @@
+package cmdprofile
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/profiles"
+)
+
+func profileDirsCommand() *cobra.Command {
+	if profileDirsCmd == nil {
+		profileDirsCmd = &cobra.Command{
+			Use:     "dirs",
+			Aliases: []string{"dir", "locations"},
+			Short:   "Lists the local vault directories registered with the active profile",
+			Run: func(cmd *cobra.Command, args []string) {
+				profile, err := profiles.GetActiveProfile()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				vaultDirs, err := profile.GetVaultDirs()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				if len(vaultDirs) == 0 {
+					fmt.Println("No vault directories registered with profile", color.GreenString(profile.Name()))
+				}
+				for _, vaultDir := range vaultDirs {
+					fmt.Println(vaultDir)
+				}
+			},
+		}
+		profileDirsCmd.AddCommand(profileDirsAddCommand())
+		profileDirsCmd.AddCommand(profileDirsRemoveCommand())
+	}
+	return profileDirsCmd
+}
+
+func profileDirsAddCommand() *cobra.Command {
+	if profileDirsAddCmd == nil {
+		profileDirsAddCmd = &cobra.Command{
+			Use:   "add <dir>...",
+			Short: "Registers directories holding vaults with the active profile",
+			Args:  cobra.MinimumNArgs(1),
+			Run: func(cmd *cobra.Command, args []string) {
+				profile, err := profiles.GetActiveProfile()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				for _, dir := range args {
+					if err = profile.AddVaultDir(dir); err != nil {
+						utils.ExitOnError(fmt.Errorf("%s: %w", dir, err))
+					}
+					fmt.Println("Registered vault directory:", color.GreenString(dir))
+				}
+			},
+		}
+	}
+	return profileDirsAddCmd
+}
+
+func profileDirsRemoveCommand() *cobra.Command {
+	if profileDirsRemoveCmd == nil {
+		profileDirsRemoveCmd = &cobra.Command{
+			Use:     "rm <dir>...",
+			Aliases: []string{"remove", "delete", "del"},
+			Short:   "Unregisters directories from the active profile",
+			Args:    cobra.MinimumNArgs(1),
+			Run: func(cmd *cobra.Command, args []string) {
+				profile, err := profiles.GetActiveProfile()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				for _, dir := range args {
+					if err = profile.RemoveVaultDir(dir); err != nil {
+						utils.ExitOnError(fmt.Errorf("%s: %w", dir, err))
+					}
+					fmt.Println("Unregistered vault directory:", color.GreenString(dir))
+				}
+			},
+		}
+	}
+	return profileDirsRemoveCmd
+}
@@ -20,6 +20,11 @@ func ProfileCommand() *cobra.Command {
 		profileCmd.AddCommand(profileListCommand())
 		profileCmd.AddCommand(profileDeleteCommand())
 		profileCmd.AddCommand(profileSyncCommand())
+		profileCmd.AddCommand(profileDirsCommand())
+		profileCmd.AddCommand(profileServeCommand())
+		profileCmd.AddCommand(profileSignCommand())
+		profileCmd.AddCommand(profileAdminCommand())
+		profileCmd.AddCommand(profileSettingsCommand())
 	}
 	return profileCmd
 }
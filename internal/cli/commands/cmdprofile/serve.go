@@ -0,0 +1,77 @@
+package cmdprofile
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/profiles"
+)
+
+const (
+	profileServeDefaultHost = "127.0.0.1"
+	profileServeDefaultPort = 8080
+)
+
+// isLoopbackHost reports whether the host only accepts connections from the local machine.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func profileServeCommand() *cobra.Command {
+	if profileServeCmd == nil {
+		profileServeCmd = &cobra.Command{
+			Use:   "serve",
+			Short: "Serves a directory as a writable http profile remote",
+			Long: `Serves the environments and settings manifests of a directory over HTTP, so that they can be used as an
+http profile remote that can also be written to. Changes are only accepted if they are based on the latest
+manifests, so concurrent changes are reported to the client as conflicts rather than being overwritten.
+
+It listens on 127.0.0.1 unless --host is given, which requires --auth-header for any address beyond the loopback
+interface. This is a reference server: serve it behind a reverse proxy that terminates TLS when used beyond a trusted network.`,
+			Args: cobra.NoArgs,
+			Run: func(cmd *cobra.Command, args []string) {
+				dir, _ := cmd.Flags().GetString(profileServeDirFlag.Name)
+				host, _ := cmd.Flags().GetString(profileServeHostFlag.Name)
+				port, _ := cmd.Flags().GetUint16(profileServePortFlag.Name)
+				authHeader, _ := cmd.Flags().GetString(profileServeAuthHeaderFlag.Name)
+				server, err := profiles.NewHTTPRemoteServer(dir, authHeader, func(fileName, note string) {
+					fmt.Printf("%s Updated %s: %s\n", time.Now().Format(time.RFC3339), fileName, note)
+				})
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				if authHeader == "" {
+					if !isLoopbackHost(host) {
+						utils.ExitOnErrorWithMessage("--" + profileServeAuthHeaderFlag.Name + " is required to listen on " + host + ", as anyone who can reach the server could change the profile")
+					}
+					fmt.Println(color.YellowString("No --" + profileServeAuthHeaderFlag.Name + " given: anyone on this machine can change the profile."))
+				}
+				address := net.JoinHostPort(host, strconv.Itoa(int(port)))
+				fmt.Printf("Serving the profile in %s on %s\n", color.GreenString(dir), color.GreenString(address))
+				httpServer := &http.Server{
+					Addr:              address,
+					Handler:           server,
+					ReadHeaderTimeout: 10 * time.Second,
+				}
+				if err = httpServer.ListenAndServe(); err != nil {
+					utils.ExitOnError(err)
+				}
+			},
+		}
+		profileServeCmd.Flags().String(profileServeDirFlag.Name, ".", profileServeDirFlag.Usage)
+		profileServeCmd.Flags().String(profileServeHostFlag.Name, profileServeDefaultHost, profileServeHostFlag.Usage)
+		profileServeCmd.Flags().Uint16P(profileServePortFlag.Name, profileServePortFlag.Shorthand, profileServeDefaultPort, profileServePortFlag.Usage)
+		profileServeCmd.Flags().String(profileServeAuthHeaderFlag.Name, "", profileServeAuthHeaderFlag.Usage)
+	}
+	return profileServeCmd
+}
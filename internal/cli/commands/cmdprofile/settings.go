@@ -0,0 +1,84 @@
+package cmdprofile
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/profiles"
+	"slv.sh/slv/internal/core/settings"
+)
+
+func showProfileSettings() {
+	profile, err := profiles.GetActiveProfile()
+	if err != nil {
+		utils.ExitOnError(err)
+	}
+	profileSettings, err := profile.GetSettings()
+	if err != nil {
+		utils.ExitOnError(err)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.TabIndent)
+	for _, key := range settings.Keys() {
+		value, err := profileSettings.Get(key)
+		if err != nil {
+			utils.ExitOnError(err)
+		}
+		fmt.Fprintf(w, "%s:\t %s\n", key, value)
+	}
+	w.Flush()
+}
+
+func profileSettingsCommand() *cobra.Command {
+	if profileSettingsCmd == nil {
+		profileSettingsCmd = &cobra.Command{
+			Use:   "settings",
+			Short: "Shows or changes the settings of the active profile",
+			Run: func(cmd *cobra.Command, args []string) {
+				showProfileSettings()
+			},
+		}
+		profileSettingsCmd.AddCommand(profileSettingsShowCommand())
+		profileSettingsCmd.AddCommand(profileSettingsSetCommand())
+	}
+	return profileSettingsCmd
+}
+
+func profileSettingsShowCommand() *cobra.Command {
+	if profileSettingsShowCmd == nil {
+		profileSettingsShowCmd = &cobra.Command{
+			Use:     "show",
+			Aliases: []string{"get", "view", "ls", "list"},
+			Short:   "Shows the settings of the active profile",
+			Run: func(cmd *cobra.Command, args []string) {
+				showProfileSettings()
+			},
+		}
+	}
+	return profileSettingsShowCmd
+}
+
+func profileSettingsSetCommand() *cobra.Command {
+	if profileSettingsSetCmd == nil {
+		profileSettingsSetCmd = &cobra.Command{
+			Use:       "set <setting> <value>",
+			Short:     "Changes a setting of the active profile and pushes it to the remote, as the root or an admin",
+			Args:      cobra.ExactArgs(2),
+			ValidArgs: settings.Keys(),
+			Run: func(cmd *cobra.Command, args []string) {
+				profile, err := profiles.GetActiveProfile()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				if err = profile.SetSetting(args[0], args[1]); err != nil {
+					utils.ExitOnError(err)
+				}
+				fmt.Printf("Set %s to %s in profile %s\n", args[0], color.GreenString(args[1]), color.GreenString(profile.Name()))
+			},
+		}
+	}
+	return profileSettingsSetCmd
+}
@@ -0,0 +1,78 @@
+package cmdprofile
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/environments"
+	"slv.sh/slv/internal/core/input"
+	"slv.sh/slv/internal/core/profiles"
+	"slv.sh/slv/internal/core/session"
+)
+
+func profileSignCommand() *cobra.Command {
+	if profileSignCmd == nil {
+		profileSignCmd = &cobra.Command{
+			Use:   "sign [public key or fingerprint]...",
+			Short: "Signs environments of the active profile as the root or an admin, so that they are trusted",
+			Long: "Signs the given environments of the active profile, or all the environments that are not trusted yet, " +
+				"with the secret key of the root or an admin of the profile. Only signed environments can be shared with " +
+				"once the root of the profile has a signing key.",
+			Run: func(cmd *cobra.Command, args []string) {
+				profile, err := profiles.GetActiveProfile()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				var envs []*environments.Environment
+				if len(args) > 0 {
+					for _, arg := range args {
+						env, err := getProfileEnv(profile, arg)
+						if err != nil {
+							utils.ExitOnError(err)
+						}
+						envs = append(envs, env)
+					}
+				} else {
+					allEnvs, err := profile.ListEnvs()
+					if err != nil {
+						utils.ExitOnError(err)
+					}
+					for _, env := range allEnvs {
+						if profile.VerifyEnv(env) != nil {
+							envs = append(envs, env)
+						}
+					}
+					if len(envs) == 0 {
+						fmt.Printf("All environments of profile %s are trusted\n", color.GreenString(profile.Name()))
+						utils.SafeExit()
+					}
+				}
+				secretKeys, err := session.GetSecretKeys()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				fmt.Println("The following environments will be signed:")
+				var publicKeys []string
+				for _, env := range envs {
+					fmt.Printf("  %s %s\n", color.CyanString(env.Name), env.PublicKey)
+					publicKeys = append(publicKeys, env.PublicKey)
+				}
+				confirm, err := input.GetConfirmation("Only sign environments you know to be genuine. Proceed [yes/no]: ", "yes")
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				if !confirm {
+					fmt.Println(color.YellowString("Signing aborted"))
+					utils.SafeExit()
+				}
+				if err = profile.SignEnvs(secretKeys, publicKeys...); err != nil {
+					utils.ExitOnError(err)
+				}
+				fmt.Printf("Signed %d environment(s) in profile %s\n", len(publicKeys), color.GreenString(profile.Name()))
+			},
+		}
+	}
+	return profileSignCmd
+}
@@ -0,0 +1,199 @@
+package cmdreport
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/helpers"
+)
+
+func reportAccessCommand() *cobra.Command {
+	if reportAccessCmd == nil {
+		reportAccessCmd = &cobra.Command{
+			Use:     "access",
+			Aliases: []string{"accessors", "matrix"},
+			Short:   "Reports which environments can access which vaults",
+			Long: `Produces a matrix of vaults and the environments that can access them.
+Environments are resolved from the active profile (and other profiles as a fallback).
+Accessors that are not found in any profile are highlighted as orphaned, and vaults
+with only one accessor are highlighted as a bus-factor risk.`,
+			Run: func(cmd *cobra.Command, args []string) {
+				dirs, _ := cmd.Flags().GetStringSlice(reportDirFlag.Name)
+				recursive, _ := cmd.Flags().GetBool(reportRecursiveFlag.Name)
+				format, _ := cmd.Flags().GetString(reportFormatFlag.Name)
+				outputFile, _ := cmd.Flags().GetString(reportOutputFlag.Name)
+				report, err := helpers.GetAccessReport(dirs, recursive)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				var write func(io.Writer) error
+				switch strings.ToLower(format) {
+				case "", "table":
+					write = func(out io.Writer) error { return writeAccessReportTable(out, report) }
+				case "csv":
+					write = func(out io.Writer) error { return writeAccessReportCSV(out, report) }
+				case "json":
+					write = func(out io.Writer) error {
+						encoder := json.NewEncoder(out)
+						encoder.SetIndent("", "  ")
+						return encoder.Encode(report)
+					}
+				case "html":
+					write = func(out io.Writer) error { return writeAccessReportHTML(out, report) }
+				default:
+					utils.ExitOnError(fmt.Errorf("invalid format: %s", format))
+				}
+				if outputFile == "" {
+					if err = write(os.Stdout); err != nil {
+						utils.ExitOnError(err)
+					}
+					utils.SafeExit()
+				}
+				text.DisableColors()
+				file, err := os.Create(outputFile)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				// The file is closed before exiting, as SafeExit does not run deferred calls
+				err = write(file)
+				if closeErr := file.Close(); err == nil {
+					err = closeErr
+				}
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				fmt.Println("Access report written to", outputFile)
+				utils.SafeExit()
+			},
+		}
+		reportAccessCmd.Flags().StringSliceP(reportDirFlag.Name, reportDirFlag.Shorthand, []string{}, reportDirFlag.Usage)
+		reportAccessCmd.Flags().BoolP(reportRecursiveFlag.Name, reportRecursiveFlag.Shorthand, false, reportRecursiveFlag.Usage)
+		reportAccessCmd.Flags().String(reportFormatFlag.Name, "table", reportFormatFlag.Usage)
+		reportAccessCmd.Flags().StringP(reportOutputFlag.Name, reportOutputFlag.Shorthand, "", reportOutputFlag.Usage)
+	}
+	return reportAccessCmd
+}
+
+func envLabel(index int) string {
+	return "E" + strconv.Itoa(index+1)
+}
+
+func envDisplayName(env *helpers.AccessReportEnv) string {
+	if env.Name != "" {
+		return env.Name
+	}
+	return env.PublicKey
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func writeAccessReportTable(out io.Writer, report *helpers.AccessReport) error {
+	envTable := table.NewWriter()
+	envTable.SetOutputMirror(out)
+	envTable.AppendHeader(table.Row{
+		text.Colors{text.Bold}.Sprint("ID"),
+		text.Colors{text.Bold}.Sprint("Name"),
+		text.Colors{text.Bold}.Sprint("Type"),
+		text.Colors{text.Bold}.Sprint("Email"),
+		text.Colors{text.Bold}.Sprint("Tags"),
+		text.Colors{text.Bold}.Sprint("Vaults"),
+		text.Colors{text.Bold}.Sprint("Public Key"),
+	})
+	for i, env := range report.Envs {
+		name := env.Name
+		if env.Root {
+			name += " (root)"
+		}
+		if env.Orphaned {
+			name = text.Colors{text.FgRed}.Sprint(strings.TrimSpace(name + " (orphaned)"))
+		}
+		envTable.AppendRow(table.Row{envLabel(i), name, env.Type, env.Email, strings.Join(env.Tags, ", "), env.Vaults, env.PublicKey})
+	}
+	envTable.SetStyle(table.StyleLight)
+	envTable.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 7, WidthMax: 40},
+	})
+	envTable.Render()
+	fmt.Fprintln(out)
+
+	matrixTable := table.NewWriter()
+	matrixTable.SetOutputMirror(out)
+	header := table.Row{
+		text.Colors{text.Bold}.Sprint("Vault File"),
+		text.Colors{text.Bold}.Sprint("Last Encrypted"),
+	}
+	for i := range report.Envs {
+		header = append(header, text.Colors{text.Bold}.Sprint(envLabel(i)))
+	}
+	matrixTable.AppendHeader(header)
+	for _, vault := range report.Vaults {
+		vaultFile := vault.File
+		switch {
+		case vault.Error != "":
+			vaultFile = text.Colors{text.FgRed}.Sprint(vaultFile + " (" + vault.Error + ")")
+		case vault.SingleAccessor:
+			vaultFile = text.Colors{text.FgYellow}.Sprint(vaultFile + " (single accessor)")
+		}
+		row := table.Row{vaultFile, formatTime(vault.LastEncryptedAt)}
+		for _, env := range report.Envs {
+			if vault.HasAccess(env.PublicKey) {
+				if env.Orphaned {
+					row = append(row, text.Colors{text.FgRed}.Sprint("✔"))
+				} else {
+					row = append(row, "✔")
+				}
+			} else {
+				row = append(row, "")
+			}
+		}
+		matrixTable.AppendRow(row)
+	}
+	matrixTable.SetStyle(table.StyleLight)
+	matrixTable.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, WidthMax: 50, Align: text.AlignLeft},
+	})
+	matrixTable.Render()
+	return nil
+}
+
+func writeAccessReportCSV(out io.Writer, report *helpers.AccessReport) error {
+	w := csv.NewWriter(out)
+	if err := w.Write([]string{"vault_file", "vault_name", "last_encrypted_at", "single_accessor",
+		"env_public_key", "env_name", "env_type", "env_email", "env_tags", "env_profile", "orphaned"}); err != nil {
+		return err
+	}
+	for _, vault := range report.Vaults {
+		if len(vault.Accessors) == 0 {
+			if err := w.Write([]string{vault.File, vault.Name, formatTime(vault.LastEncryptedAt), "false",
+				"", "", "", "", "", "", ""}); err != nil {
+				return err
+			}
+		}
+		for _, env := range report.Envs {
+			if !vault.HasAccess(env.PublicKey) {
+				continue
+			}
+			if err := w.Write([]string{vault.File, vault.Name, formatTime(vault.LastEncryptedAt), strconv.FormatBool(vault.SingleAccessor),
+				env.PublicKey, env.Name, env.Type, env.Email, strings.Join(env.Tags, ";"), env.Profile, strconv.FormatBool(env.Orphaned)}); err != nil {
+				return err
+			}
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
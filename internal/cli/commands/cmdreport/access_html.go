@@ -0,0 +1,69 @@
+package cmdreport
+
+import (
+	"html/template"
+	"io"
+	"strings"
+
+	"slv.sh/slv/internal/helpers"
+)
+
+const accessReportHTMLTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>SLV Access Report</title>
+<style>
+  body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, sans-serif; margin: 2rem; color: #1f2328; }
+  h1 { font-size: 1.5rem; }
+  h2 { font-size: 1.2rem; margin-top: 2rem; }
+  table { border-collapse: collapse; margin-top: 0.5rem; }
+  th, td { border: 1px solid #d0d7de; padding: 0.3rem 0.6rem; text-align: left; font-size: 0.9rem; }
+  th { background: #f6f8fa; }
+  td.access { text-align: center; }
+  .orphaned { background: #ffebe9; color: #cf222e; }
+  .single { background: #fff8c5; }
+  .error { color: #cf222e; }
+  .pubkey { font-family: monospace; font-size: 0.75rem; word-break: break-all; }
+  .legend span { display: inline-block; padding: 0.1rem 0.5rem; margin-right: 0.5rem; }
+</style>
+</head>
+<body>
+<h1>SLV Access Report</h1>
+<p>Generated at {{ .Report.GeneratedAt.Format "2006-01-02T15:04:05Z07:00" }}{{ if .Report.Profile }} using profile <strong>{{ .Report.Profile }}</strong>{{ end }}</p>
+<p class="legend"><span class="orphaned">Orphaned key (not in any profile)</span><span class="single">Vault with a single accessor</span></p>
+<h2>Environments</h2>
+<table>
+<tr><th>ID</th><th>Name</th><th>Type</th><th>Email</th><th>Tags</th><th>Profile</th><th>Vaults</th><th>Public Key</th></tr>
+{{- range $i, $env := .Report.Envs }}
+<tr{{ if $env.Orphaned }} class="orphaned"{{ end }}><td>{{ label $i }}</td><td>{{ $env.Name }}{{ if $env.Root }} (root){{ end }}</td><td>{{ $env.Type }}</td><td>{{ $env.Email }}</td><td>{{ join $env.Tags }}</td><td>{{ $env.Profile }}</td><td>{{ $env.Vaults }}</td><td class="pubkey">{{ $env.PublicKey }}</td></tr>
+{{- end }}
+</table>
+<h2>Access Matrix</h2>
+<table>
+<tr><th>Vault File</th><th>Name</th><th>Items</th><th>Last Encrypted</th>{{ range $i, $env := .Report.Envs }}<th title="{{ $env.PublicKey }}"{{ if $env.Orphaned }} class="orphaned"{{ end }}>{{ label $i }}<br>{{ name $env }}</th>{{ end }}</tr>
+{{- range $vault := .Report.Vaults }}
+<tr{{ if $vault.SingleAccessor }} class="single"{{ end }}><td>{{ $vault.File }}{{ if $vault.Error }} <span class="error">({{ $vault.Error }})</span>{{ end }}</td><td>{{ $vault.Name }}</td><td>{{ $vault.Items }}</td><td>{{ time $vault.LastEncryptedAt }}</td>
+{{- range $env := $.Report.Envs }}<td class="access{{ if and $env.Orphaned ($vault.HasAccess $env.PublicKey) }} orphaned{{ end }}">{{ if $vault.HasAccess $env.PublicKey }}&#10004;{{ end }}</td>{{ end }}</tr>
+{{- end }}
+</table>
+</body>
+</html>
+`
+
+func writeAccessReportHTML(out io.Writer, report *helpers.AccessReport) error {
+	tmpl, err := template.New("report").Funcs(template.FuncMap{
+		"label": envLabel,
+		"name":  envDisplayName,
+		"time":  formatTime,
+		"join": func(values []string) string {
+			return strings.Join(values, ", ")
+		},
+	}).Parse(accessReportHTMLTemplate)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(out, struct {
+		Report *helpers.AccessReport
+	}{report})
+}
@@ -0,0 +1,36 @@
+package cmdreport
+
+import (
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+)
+
+var (
+	reportCmd       *cobra.Command
+	reportAccessCmd *cobra.Command
+)
+
+var (
+	reportDirFlag = utils.FlagDef{
+		Name:      "dir",
+		Shorthand: "d",
+		Usage:     "Directories to search for vaults (default: current directory)",
+	}
+
+	reportRecursiveFlag = utils.FlagDef{
+		Name:      "recursive",
+		Shorthand: "r",
+		Usage:     "Search for vaults recursively in subdirectories",
+	}
+
+	reportFormatFlag = utils.FlagDef{
+		Name:  "format",
+		Usage: "Report format as one of [table, csv, json, html]",
+	}
+
+	reportOutputFlag = utils.FlagDef{
+		Name:      "output",
+		Shorthand: "o",
+		Usage:     "Writes the report to the given file instead of stdout",
+	}
+)
@@ -0,0 +1,20 @@
+package cmdreport
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func ReportCommand() *cobra.Command {
+	if reportCmd == nil {
+		reportCmd = &cobra.Command{
+			Use:     "report",
+			Aliases: []string{"reports"},
+			Short:   "Generate reports about vaults and environments",
+			Run: func(cmd *cobra.Command, args []string) {
+				cmd.Help()
+			},
+		}
+		reportCmd.AddCommand(reportAccessCommand())
+	}
+	return reportCmd
+}
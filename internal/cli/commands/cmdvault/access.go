@@ -2,14 +2,20 @@ package cmdvault
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
 	"github.com/spf13/cobra"
 	"slv.sh/slv/internal/cli/commands/cmdenv"
 	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/audit"
 	"slv.sh/slv/internal/core/crypto"
 	"slv.sh/slv/internal/core/session"
 	"slv.sh/slv/internal/core/vaults"
+	"slv.sh/slv/internal/helpers"
 )
 
 func vaultAccessCommand() *cobra.Command {
@@ -22,6 +28,11 @@ func vaultAccessCommand() *cobra.Command {
 				cmd.Help()
 			},
 		}
+		// The access commands take either --vault or --dir, so they shadow the --vault flag that the vault command requires.
+		vaultAccessCmd.PersistentFlags().StringP(vaultFileFlag.Name, vaultFileFlag.Shorthand, "", vaultFileFlag.Usage+" (or --dir to change access in bulk)")
+		if err := vaultAccessCmd.RegisterFlagCompletionFunc(vaultFileFlag.Name, vaultFilePathCompletion); err != nil {
+			utils.ExitOnError(err)
+		}
 		vaultAccessCmd.PersistentFlags().StringSliceP(cmdenv.EnvPublicKeysFlag.Name, cmdenv.EnvPublicKeysFlag.Shorthand, []string{}, cmdenv.EnvPublicKeysFlag.Usage)
 		vaultAccessCmd.PersistentFlags().StringSliceP(cmdenv.EnvSearchFlag.Name, cmdenv.EnvSearchFlag.Shorthand, []string{}, cmdenv.EnvSearchFlag.Usage)
 		if err := vaultAccessCmd.RegisterFlagCompletionFunc(cmdenv.EnvSearchFlag.Name, cmdenv.EnvSearchCompletion); err != nil {
@@ -30,12 +41,47 @@ func vaultAccessCommand() *cobra.Command {
 		vaultAccessCmd.PersistentFlags().BoolP(cmdenv.EnvSelfFlag.Name, cmdenv.EnvSelfFlag.Shorthand, false, cmdenv.EnvSelfFlag.Usage)
 		vaultAccessCmd.PersistentFlags().BoolP(cmdenv.EnvK8sFlag.Name, cmdenv.EnvK8sFlag.Shorthand, false, cmdenv.EnvK8sFlag.Usage)
 		vaultAccessCmd.PersistentFlags().BoolP(utils.QuantumSafeFlag.Name, utils.QuantumSafeFlag.Shorthand, false, utils.QuantumSafeFlag.Usage+" (used with k8s environment)")
+		vaultAccessCmd.PersistentFlags().StringP(listDirFlag.Name, listDirFlag.Shorthand, "", "Directory with vaults to change access in bulk (used instead of --vault)")
+		vaultAccessCmd.PersistentFlags().BoolP(listRecursiveFlag.Name, listRecursiveFlag.Shorthand, false, listRecursiveFlag.Usage+" (used with --dir)")
+		vaultAccessCmd.PersistentFlags().StringSliceP(vaultMatchFlag.Name, vaultMatchFlag.Shorthand, []string{}, vaultMatchFlag.Usage)
+		vaultAccessCmd.PersistentFlags().Bool(accessDryRunFlag.Name, false, accessDryRunFlag.Usage+" (used with --dir)")
+		vaultAccessCmd.AddCommand(vaultAccessListCommand())
 		vaultAccessCmd.AddCommand(vaultAccessAddCommand())
 		vaultAccessCmd.AddCommand(vaultAccessRemoveCommand())
+		for _, bulkCmd := range []*cobra.Command{vaultAccessAddCmd, vaultAccessRemoveCmd} {
+			bulkCmd.MarkFlagsOneRequired(vaultFileFlag.Name, listDirFlag.Name)
+			bulkCmd.MarkFlagsMutuallyExclusive(vaultFileFlag.Name, listDirFlag.Name)
+		}
 	}
 	return vaultAccessCmd
 }
 
+func vaultAccessListCommand() *cobra.Command {
+	if vaultAccessListCmd == nil {
+		vaultAccessListCmd = &cobra.Command{
+			Use:     "list",
+			Aliases: []string{"ls", "show"},
+			Short:   "Lists the environments that have access to a vault",
+			PreRunE: func(cmd *cobra.Command, args []string) error {
+				if !cmd.Flags().Changed(vaultFileFlag.Name) {
+					return fmt.Errorf("required flag(s) \"%s\" not set", vaultFileFlag.Name)
+				}
+				return nil
+			},
+			Run: func(cmd *cobra.Command, args []string) {
+				vaultFile := cmd.Flag(vaultFileFlag.Name).Value.String()
+				vault, err := vaults.Get(vaultFile)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				getAccessorsTable(vault).Render()
+				utils.SafeExit()
+			},
+		}
+	}
+	return vaultAccessListCmd
+}
+
 func vaultAccessAddCommand() *cobra.Command {
 	if vaultAccessAddCmd == nil {
 		vaultAccessAddCmd = &cobra.Command{
@@ -43,7 +89,7 @@ func vaultAccessAddCommand() *cobra.Command {
 			Aliases: []string{"allow", "add", "share"},
 			Short:   "Grants read access to a vault for the given environments/public keys",
 			Run: func(cmd *cobra.Command, args []string) {
-				envSecretKey, err := session.GetSecretKey()
+				envSecretKeys, err := session.GetSecretKeys()
 				if err != nil {
 					utils.ExitOnError(err)
 				}
@@ -53,10 +99,23 @@ func vaultAccessAddCommand() *cobra.Command {
 				if err != nil {
 					utils.ExitOnError(err)
 				}
+				if err = helpers.CheckVaultSharing(publicKeys); err != nil {
+					utils.ExitOnError(err)
+				}
+				if cmd.Flags().Changed(listDirFlag.Name) {
+					dir, vaultFiles := getBulkAccessVaultFiles(cmd)
+					dryRun, _ := cmd.Flags().GetBool(accessDryRunFlag.Name)
+					if !dryRun {
+						auditAccessResults(audit.Grant, dir, helpers.GrantVaultAccess(dir, vaultFiles, envSecretKeys, publicKeys, true), publicKeys)
+					}
+					results := helpers.GrantVaultAccess(dir, vaultFiles, envSecretKeys, publicKeys, dryRun)
+					showBulkAccessResults(results, dryRun, "Granted")
+				}
 				vault, err := vaults.Get(vaultFile)
 				if err == nil {
-					err = vault.Unlock(envSecretKey)
+					err = vault.Unlock(envSecretKeys...)
 					if err == nil {
+						auditAccessChange(audit.Grant, vaultFile, publicKeys)
 						for _, publicKey := range publicKeys {
 							if _, err = vault.Share(publicKey); err != nil {
 								break
@@ -88,14 +147,28 @@ func vaultAccessRemoveCommand() *cobra.Command {
 				if err != nil {
 					utils.ExitOnError(err)
 				}
+				if cmd.Flags().Changed(listDirFlag.Name) {
+					envSecretKeys, err := session.GetSecretKeys()
+					if err != nil {
+						utils.ExitOnError(err)
+					}
+					dir, vaultFiles := getBulkAccessVaultFiles(cmd)
+					dryRun, _ := cmd.Flags().GetBool(accessDryRunFlag.Name)
+					if !dryRun {
+						auditAccessResults(audit.Revoke, dir, helpers.RevokeVaultAccess(dir, vaultFiles, envSecretKeys, publicKeys, k8sPQ, true), publicKeys)
+					}
+					results := helpers.RevokeVaultAccess(dir, vaultFiles, envSecretKeys, publicKeys, k8sPQ, dryRun)
+					showBulkAccessResults(results, dryRun, "Revoked")
+				}
 				vault, err := vaults.Get(vaultFile)
 				if err == nil {
-					var envSecretKey *crypto.SecretKey
-					if envSecretKey, err = session.GetSecretKey(); err == nil {
-						err = vault.Unlock(envSecretKey)
+					var envSecretKeys []*crypto.SecretKey
+					if envSecretKeys, err = session.GetSecretKeys(); err == nil {
+						err = vault.Unlock(envSecretKeys...)
 					}
 					if err == nil {
 						pq, _ := cmd.Flags().GetBool(utils.QuantumSafeFlag.Name)
+						auditAccessChange(audit.Revoke, vaultFile, publicKeys)
 						if err = vault.Revoke(publicKeys, pq); err == nil {
 							fmt.Println("Revoked vault access:", color.GreenString(vaultFile))
 							utils.SafeExit()
@@ -108,3 +181,87 @@ func vaultAccessRemoveCommand() *cobra.Command {
 	}
 	return vaultAccessRemoveCmd
 }
+
+func getBulkAccessVaultFiles(cmd *cobra.Command) (string, []string) {
+	dir := cmd.Flag(listDirFlag.Name).Value.String()
+	recursive, _ := cmd.Flags().GetBool(listRecursiveFlag.Name)
+	patterns, _ := cmd.Flags().GetStringSlice(vaultMatchFlag.Name)
+	vaultFiles, err := helpers.ListVaultFiles(dir, recursive)
+	if err != nil {
+		utils.ExitOnError(err)
+	}
+	if vaultFiles, err = helpers.MatchVaultFiles(vaultFiles, patterns); err != nil {
+		utils.ExitOnError(err)
+	}
+	if len(vaultFiles) == 0 {
+		fmt.Println("No vaults found in the specified directory.")
+		utils.SafeExit()
+	}
+	return dir, vaultFiles
+}
+
+func showBulkAccessResults(results []*helpers.VaultAccessResult, dryRun bool, action string) {
+	resultTable := table.NewWriter()
+	resultTable.SetOutputMirror(os.Stdout)
+	resultTable.AppendHeader(table.Row{
+		text.Colors{text.Bold}.Sprint("Vault File"),
+		text.Colors{text.Bold}.Sprint("Result"),
+	})
+	changed, unchanged, skipped, failed := 0, 0, 0, 0
+	for _, result := range results {
+		var status string
+		switch {
+		case result.Skipped:
+			skipped++
+			status = text.Colors{text.FgYellow}.Sprint("Skipped (" + result.Err.Error() + ")")
+		case result.Failed():
+			failed++
+			status = text.Colors{text.FgRed}.Sprint("Failed (" + result.Err.Error() + ")")
+		case result.Changed:
+			changed++
+			if dryRun {
+				status = text.Colors{text.FgGreen}.Sprint("Will be changed")
+			} else {
+				status = text.Colors{text.FgGreen}.Sprint(action)
+			}
+		default:
+			unchanged++
+			status = "No change"
+		}
+		resultTable.AppendRow(table.Row{result.VaultFile, status})
+	}
+	resultTable.SetStyle(table.StyleLight)
+	resultTable.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, WidthMax: 50, Align: text.AlignLeft},
+		{Number: 2, WidthMax: 60, Align: text.AlignLeft},
+	})
+	resultTable.Render()
+	if dryRun {
+		fmt.Printf("Dry run: %d vault(s) will be changed, %d unchanged, %d skipped, %d failed\n", changed, unchanged, skipped, failed)
+	} else {
+		fmt.Printf("%s: %d vault(s) changed, %d unchanged, %d skipped, %d failed\n", action, changed, unchanged, skipped, failed)
+	}
+	if failed > 0 {
+		utils.ExitOnErrorWithMessage(fmt.Sprintf("failed to update %d vault(s)", failed))
+	}
+	utils.SafeExit()
+}
+
+func auditAccessChange(action audit.Action, vaultFile string, publicKeys []*crypto.PublicKey) {
+	entry := &audit.Entry{Action: action, Vault: vaultFile}
+	for _, publicKey := range publicKeys {
+		if publicKeyStr, err := publicKey.String(); err == nil {
+			entry.Envs = append(entry.Envs, publicKeyStr)
+		}
+	}
+	utils.AuditLog(entry)
+}
+
+// auditAccessResults records the access changes that the results of a dry run report, before they are made.
+func auditAccessResults(action audit.Action, dir string, results []*helpers.VaultAccessResult, publicKeys []*crypto.PublicKey) {
+	for _, result := range results {
+		if result.Changed && result.Err == nil {
+			auditAccessChange(action, filepath.Join(dir, result.VaultFile), publicKeys)
+		}
+	}
+}
@@ -11,6 +11,7 @@ var (
 	vaultNewCmd          *cobra.Command
 	vaultUpdateCmd       *cobra.Command
 	vaultAccessCmd       *cobra.Command
+	vaultAccessListCmd   *cobra.Command
 	vaultAccessAddCmd    *cobra.Command
 	vaultAccessRemoveCmd *cobra.Command
 	vaultPutCmd          *cobra.Command
@@ -19,6 +20,12 @@ var (
 	vaultRunCmd          *cobra.Command
 	vaultRefCmd          *cobra.Command
 	vaultDerefCmd        *cobra.Command
+	vaultVerifyCmd       *cobra.Command
+	vaultSignCmd         *cobra.Command
+	vaultWriterCmd       *cobra.Command
+	vaultWriterAddCmd    *cobra.Command
+	vaultWriterRemoveCmd *cobra.Command
+	vaultUpgradeCmd      *cobra.Command
 )
 
 var (
@@ -116,6 +123,17 @@ var (
 		Usage: "Path to the YAML/JSON/blob file to be referenced",
 	}
 
+	vaultMatchFlag = utils.FlagDef{
+		Name:      "match",
+		Shorthand: "m",
+		Usage:     "Glob pattern(s) to select vaults relative to --dir (e.g. 'team-a/**')",
+	}
+
+	accessDryRunFlag = utils.FlagDef{
+		Name:  "dry-run",
+		Usage: "Shows the vaults that would be changed without modifying them",
+	}
+
 	secretSubstitutionPreviewOnlyFlag = utils.FlagDef{
 		Name:  "preview",
 		Usage: "Enables preview mode (shows the substitution result without writing to the file)",
@@ -6,6 +6,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/audit"
 	"slv.sh/slv/internal/core/vaults"
 )
 
@@ -26,11 +27,13 @@ func vaultDeleteCommand() *cobra.Command {
 					utils.ExitOnError(err)
 				}
 				if len(secretNames) == 0 {
+					utils.AuditLog(&audit.Entry{Action: audit.Delete, Vault: vaultFile})
 					if err = vault.Delete(); err != nil {
 						utils.ExitOnError(err)
 					}
 					fmt.Printf(color.GreenString("Successfully deleted the vault: %s\n"), vaultFile)
 				} else {
+					utils.AuditLog(&audit.Entry{Action: audit.Delete, Vault: vaultFile, Items: secretNames})
 					if err = vault.DeleteItems(secretNames); err != nil {
 						utils.ExitOnError(err)
 					}
@@ -6,6 +6,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/audit"
 	"slv.sh/slv/internal/core/session"
 	"slv.sh/slv/internal/core/vaults"
 )
@@ -16,7 +17,7 @@ func vaultDerefCommand() *cobra.Command {
 			Use:   "deref",
 			Short: "Dereferences and updates values from a vault to a given file with vault references",
 			Run: func(cmd *cobra.Command, args []string) {
-				envSecretKey, err := session.GetSecretKey()
+				envSecretKeys, err := session.GetSecretKeys()
 				if err != nil {
 					utils.ExitOnError(err)
 				}
@@ -33,7 +34,8 @@ func vaultDerefCommand() *cobra.Command {
 				if err != nil {
 					utils.ExitOnError(err)
 				}
-				err = vault.Unlock(envSecretKey)
+				utils.AuditLog(&audit.Entry{Action: audit.Deref, Vault: vaultFile, File: file})
+				err = vault.Unlock(envSecretKeys...)
 				if err != nil {
 					utils.ExitOnError(err)
 				}
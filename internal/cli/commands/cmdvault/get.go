@@ -10,23 +10,29 @@ import (
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/audit"
 	"slv.sh/slv/internal/core/session"
 	"slv.sh/slv/internal/core/vaults"
 )
 
-func unlockVault(vault *vaults.Vault) {
+func unlockVault(vault *vaults.Vault, vaultFile, itemName string) {
+	entry := &audit.Entry{Action: audit.Get, Vault: vaultFile}
+	if itemName != "" {
+		entry.Items = []string{itemName}
+	}
+	utils.AuditLog(entry)
 	if vault.IsLocked() {
-		envSecretKey, err := session.GetSecretKey()
+		envSecretKeys, err := session.GetSecretKeys()
 		if err != nil {
 			utils.ExitOnError(err)
 		}
-		if err = vault.Unlock(envSecretKey); err != nil {
+		if err = vault.Unlock(envSecretKeys...); err != nil {
 			utils.ExitOnError(err)
 		}
 	}
 }
 
-func getVaultItemMap(vault *vaults.Vault, itemName string, encodeToBase64, withMetadata bool) map[string]any {
+func getVaultItemMap(vault *vaults.Vault, vaultFile, itemName string, encodeToBase64, withMetadata bool) map[string]any {
 	type itemInfo struct {
 		Value       string `json:"value,omitempty" yaml:"value,omitempty"`
 		IsPlaintext bool   `json:"isPlaintext,omitempty" yaml:"isPlaintext,omitempty"`
@@ -37,7 +43,7 @@ func getVaultItemMap(vault *vaults.Vault, itemName string, encodeToBase64, withM
 	var vaultItemMap map[string]*vaults.VaultItem
 	var err error
 	if itemName == "" {
-		unlockVault(vault)
+		unlockVault(vault, vaultFile, "")
 		if vaultItemMap, err = vault.GetAllItems(); err != nil {
 			utils.ExitOnError(err)
 		}
@@ -47,7 +53,7 @@ func getVaultItemMap(vault *vaults.Vault, itemName string, encodeToBase64, withM
 			utils.ExitOnError(fmt.Errorf("item %s not found", itemName))
 		}
 		if !item.IsPlaintext() {
-			unlockVault(vault)
+			unlockVault(vault, vaultFile, itemName)
 		}
 		if item, err = vault.Get(itemName); err != nil {
 			utils.ExitOnError(err)
@@ -102,21 +108,21 @@ func vaultGetCommand() *cobra.Command {
 				exportFormat := cmd.Flag(vaultExportFormatFlag.Name).Value.String()
 				switch exportFormat {
 				case "json":
-					viMap := getVaultItemMap(vault, itemName, encodeToBase64, withMetadata)
+					viMap := getVaultItemMap(vault, vaultFile, itemName, encodeToBase64, withMetadata)
 					jsonData, err := json.MarshalIndent(viMap, "", "  ")
 					if err != nil {
 						utils.ExitOnError(err)
 					}
 					fmt.Println(string(jsonData))
 				case "yaml", "yml":
-					dataMap := getVaultItemMap(vault, itemName, encodeToBase64, withMetadata)
+					dataMap := getVaultItemMap(vault, vaultFile, itemName, encodeToBase64, withMetadata)
 					yamlData, err := yaml.Marshal(dataMap)
 					if err != nil {
 						utils.ExitOnError(err)
 					}
 					fmt.Println(string(yamlData))
 				case "envars", "envar", "env", ".env":
-					dataMap := getVaultItemMap(vault, itemName, encodeToBase64, false)
+					dataMap := getVaultItemMap(vault, vaultFile, itemName, encodeToBase64, false)
 					for key, value := range dataMap {
 						strValue := value.(string)
 						strValue = strings.ReplaceAll(strValue, "\\", "\\\\")
@@ -125,7 +131,7 @@ func vaultGetCommand() *cobra.Command {
 					}
 				default:
 					if itemName == "" {
-						unlockVault(vault)
+						unlockVault(vault, vaultFile, "")
 						showVault(vault)
 					} else {
 						if !vault.ItemExists(itemName) {
@@ -136,7 +142,7 @@ func vaultGetCommand() *cobra.Command {
 							utils.ExitOnError(err)
 						}
 						if !item.IsPlaintext() {
-							unlockVault(vault)
+							unlockVault(vault, vaultFile, itemName)
 						}
 						if itemValueStr, err := item.ValueString(); err != nil {
 							utils.ExitOnError(err)
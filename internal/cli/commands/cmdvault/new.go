@@ -8,6 +8,7 @@ import (
 	"slv.sh/slv/internal/cli/commands/cmdenv"
 	"slv.sh/slv/internal/cli/commands/utils"
 	"slv.sh/slv/internal/core/vaults"
+	"slv.sh/slv/internal/helpers"
 )
 
 func vaultNewCommand() *cobra.Command {
@@ -23,12 +24,21 @@ func vaultNewCommand() *cobra.Command {
 				if err != nil {
 					utils.ExitOnError(err)
 				}
+				if err = helpers.CheckVaultSharing(publicKeys); err != nil {
+					utils.ExitOnError(err)
+				}
 				enableHash, _ := cmd.Flags().GetBool(vaultEnableHashingFlag.Name)
 				name := cmd.Flag(vaultNameFlag.Name).Value.String()
 				k8sNamespace := cmd.Flag(vaultK8sNamespaceFlag.Name).Value.String()
-				if _, err = vaults.New(vaultFile, name, k8sNamespace, enableHash, pq, publicKeys...); err != nil {
+				vault, err := vaults.New(vaultFile, name, k8sNamespace, enableHash, pq, publicKeys...)
+				if err != nil {
 					utils.ExitOnError(err)
 				}
+				if setVaultSigner(vault) {
+					if err = vault.Sign(); err != nil {
+						utils.ExitOnError(err)
+					}
+				}
 				fmt.Println("Created vault:", color.GreenString(vaultFile))
 				utils.SafeExit()
 			},
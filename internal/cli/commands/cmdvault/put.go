@@ -7,6 +7,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/audit"
 	"slv.sh/slv/internal/core/input"
 	"slv.sh/slv/internal/core/vaults"
 )
@@ -30,6 +31,7 @@ func vaultPutCommand() *cobra.Command {
 				if err != nil {
 					utils.ExitOnError(err)
 				}
+				setVaultSigner(vault)
 				forceUpdate, _ := cmd.Flags().GetBool(secretForceUpdateFlag.Name)
 				if itemName != "" {
 					if !forceUpdate && vault.ItemExists(itemName) {
@@ -55,6 +57,7 @@ func vaultPutCommand() *cobra.Command {
 					default:
 						secret = []byte(itemValue)
 					}
+					utils.AuditLog(&audit.Entry{Action: audit.Put, Vault: vaultFile, Items: []string{itemName}})
 					if err = vault.Put(itemName, secret, !plaintextValue); err != nil {
 						utils.ExitOnError(err)
 					}
@@ -70,6 +73,7 @@ func vaultPutCommand() *cobra.Command {
 					if err != nil {
 						utils.ExitOnError(err)
 					}
+					utils.AuditLog(&audit.Entry{Action: audit.Put, Vault: vaultFile, File: importFile})
 					if err = vault.Import(importData, forceUpdate, true); err != nil {
 						utils.ExitOnError(err)
 					}
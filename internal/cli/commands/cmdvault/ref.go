@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/audit"
 	"slv.sh/slv/internal/core/vaults"
 )
 
@@ -60,6 +61,9 @@ func vaultRefCommand() *cobra.Command {
 				if secretNamePrefix == "" && refType == "" {
 					utils.ExitOnErrorWithMessage("please provide --" + itemNameFlag.Name + " since the file is neither json nor yaml")
 				}
+				if !previewMode {
+					utils.AuditLog(&audit.Entry{Action: audit.Ref, Vault: vaultFile, File: refFile})
+				}
 				result, conflicting, err := vault.Ref(refType, refFile, secretNamePrefix, forceUpdate, true, previewMode)
 				if conflicting {
 					utils.ExitOnErrorWithMessage("conflict found. please use the --" + itemNameFlag.Name + " flag to set a different name or --" + secretForceUpdateFlag.Name + " flag to overwrite them.")
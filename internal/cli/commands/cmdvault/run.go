@@ -11,6 +11,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/audit"
 	"slv.sh/slv/internal/core/config"
 	"slv.sh/slv/internal/core/session"
 	"slv.sh/slv/internal/core/vaults"
@@ -42,11 +43,12 @@ func runVaultCommand(shell bool, vaultFile, prefix, command string, args ...stri
 	if err != nil {
 		utils.ExitOnError(err)
 	}
-	envSecretKey, err := session.GetSecretKey()
+	envSecretKeys, err := session.GetSecretKeys()
 	if err != nil {
 		utils.ExitOnError(err)
 	}
-	err = vault.Unlock(envSecretKey)
+	utils.AuditLog(&audit.Entry{Action: audit.Unlock, Vault: vaultFile})
+	err = vault.Unlock(envSecretKeys...)
 	if err != nil {
 		utils.ExitOnError(err)
 	}
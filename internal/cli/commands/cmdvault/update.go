@@ -39,6 +39,7 @@ func vaultUpdateCommand() *cobra.Command {
 					if err != nil {
 						utils.ExitOnError(err)
 					}
+					setVaultSigner(vault)
 				}
 				secretType := cmd.Flag(vaultK8sSecretTypeFlag.Name).Value.String()
 				if err = vault.Update(name, namespace, secretType, data); err != nil {
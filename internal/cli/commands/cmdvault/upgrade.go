@@ -0,0 +1,54 @@
+package cmdvault
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/audit"
+	"slv.sh/slv/internal/core/session"
+	"slv.sh/slv/internal/core/vaults"
+)
+
+func vaultUpgradeCommand() *cobra.Command {
+	if vaultUpgradeCmd == nil {
+		vaultUpgradeCmd = &cobra.Command{
+			Use:   "upgrade",
+			Short: "Re-seals the vault with the latest cryptography version",
+			Long: `Re-wraps the keys and re-seals the secrets of the vault that were encrypted with an older cryptography version.
+Upgraded secrets are bound to the vault and to their names, so a sealed value can no longer be moved to another item or vault unnoticed.
+The format version is then recorded in the vault, after which secrets sealed with an older version are rejected.`,
+			Run: func(cmd *cobra.Command, args []string) {
+				vaultFile := cmd.Flag(vaultFileFlag.Name).Value.String()
+				vault, err := vaults.Get(vaultFile)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				if !vault.NeedsUpgrade() {
+					fmt.Println("Vault is already up to date:", color.GreenString(vaultFile))
+					utils.SafeExit()
+				}
+				envSecretKeys, err := session.GetSecretKeys()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				utils.AuditLog(&audit.Entry{Action: audit.Upgrade, Vault: vaultFile})
+				if err = vault.Unlock(envSecretKeys...); err != nil {
+					utils.ExitOnError(err)
+				}
+				upgraded, err := vault.Upgrade()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				if upgraded == 0 {
+					fmt.Println("Recorded the latest format version in the vault:", color.GreenString(vaultFile))
+				} else {
+					fmt.Printf("Upgraded %d entries in the vault %s\n", upgraded, color.GreenString(vaultFile))
+				}
+				utils.SafeExit()
+			},
+		}
+	}
+	return vaultUpgradeCmd
+}
@@ -3,11 +3,13 @@ package cmdvault
 import (
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/jedib0t/go-pretty/v6/text"
 	"github.com/spf13/cobra"
 	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/audit"
 	"slv.sh/slv/internal/core/environments"
 	"slv.sh/slv/internal/core/profiles"
 	"slv.sh/slv/internal/core/session"
@@ -67,6 +69,17 @@ func showVault(vault *vaults.Vault) {
 	}
 	dataTable.AppendRows(dataTableRows)
 
+	accessTable := getAccessorsTable(vault)
+
+	fmt.Printf("Vault Name: %s\n", vault.Name)
+	fmt.Println("Vault Data:")
+	dataTable.SetStyle(table.StyleLight)
+	dataTable.Render()
+	fmt.Println("Accessible by:")
+	accessTable.Render()
+}
+
+func getAccessorsTable(vault *vaults.Vault) table.Writer {
 	accessors, err := vault.ListAccessors()
 	if err != nil {
 		utils.ExitOnError(err)
@@ -81,6 +94,7 @@ func showVault(vault *vaults.Vault) {
 	accessTable.SetOutputMirror(os.Stdout)
 	accessTable.AppendHeader(table.Row{
 		text.Colors{text.Bold}.Sprint("Public Key"),
+		text.Colors{text.Bold}.Sprint("Fingerprint"),
 		text.Colors{text.Bold}.Sprint("Type"),
 		text.Colors{text.Bold}.Sprint("Name"),
 	})
@@ -90,7 +104,7 @@ func showVault(vault *vaults.Vault) {
 		if err != nil {
 			utils.ExitOnError(err)
 		}
-		row := table.Row{accessorPubKey}
+		row := table.Row{accessorPubKey, accessor.Fingerprint()}
 		if self != nil && self.PublicKey == accessorPubKey {
 			row = append(row, "Self", self.Name)
 		} else if root != nil && root.PublicKey == accessorPubKey {
@@ -104,20 +118,14 @@ func showVault(vault *vaults.Vault) {
 				}
 			}
 		}
-		if len(row) < 3 {
+		if len(row) < 4 {
 			row = append(row, "Unknown", "")
 		}
 		accessTableRows = append(accessTableRows, row)
 	}
 	accessTable.AppendRows(accessTableRows)
-
-	fmt.Printf("Vault Name: %s\n", vault.Name)
-	fmt.Println("Vault Data:")
-	dataTable.SetStyle(table.StyleLight)
-	dataTable.Render()
-	fmt.Println("Accessible by:")
 	accessTable.SetStyle(table.StyleLight)
-	accessTable.Render()
+	return accessTable
 }
 
 func VaultCommand() *cobra.Command {
@@ -132,9 +140,10 @@ func VaultCommand() *cobra.Command {
 				if err != nil {
 					utils.ExitOnError(err)
 				}
-				envSecretKey, _ := session.GetSecretKey()
-				if envSecretKey != nil {
-					vault.Unlock(envSecretKey)
+				envSecretKeys, _ := session.GetSecretKeys()
+				if slices.ContainsFunc(envSecretKeys, vault.IsAccessibleBy) {
+					utils.AuditLog(&audit.Entry{Action: audit.Unlock, Vault: vaultFile})
+					vault.Unlock(envSecretKeys...)
 				}
 				showVault(vault)
 			},
@@ -154,10 +163,24 @@ func VaultCommand() *cobra.Command {
 		vaultCmd.AddCommand(vaultRefCommand())
 		vaultCmd.AddCommand(vaultDerefCommand())
 		vaultCmd.AddCommand(vaultAccessCommand())
+		vaultCmd.AddCommand(vaultVerifyCommand())
+		vaultCmd.AddCommand(vaultSignCommand())
+		vaultCmd.AddCommand(vaultWriterCommand())
+		vaultCmd.AddCommand(vaultUpgradeCommand())
 	}
 	return vaultCmd
 }
 
+// setVaultSigner makes the session environment sign the changes made to the vault when it has access to the vault
+func setVaultSigner(vault *vaults.Vault) bool {
+	envSecretKeys, _ := session.GetSecretKeys()
+	signed, err := helpers.SetVaultSigner(vault, envSecretKeys...)
+	if err != nil {
+		utils.ExitOnError(err)
+	}
+	return signed
+}
+
 func vaultFilePathCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if vaultFiles, err := helpers.ListVaultFiles("", true); err != nil {
 		return nil, cobra.ShellCompDirectiveError
@@ -0,0 +1,123 @@
+package cmdvault
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/vaults"
+	"slv.sh/slv/internal/helpers"
+)
+
+func vaultVerifyCommand() *cobra.Command {
+	if vaultVerifyCmd == nil {
+		vaultVerifyCmd = &cobra.Command{
+			Use:   "verify",
+			Short: "Verifies that the vault contents are signed by environments authorized to write to it",
+			Long: `Checks the signatures on the wrapped keys, on every item and on every file key of the vault.
+A signature is accepted only if it is made by an authorized writer of the vault: the root or an admin of the
+active profile (or the session environment for profiles that do not sign their environments), or an environment
+they listed as a writer in the vault (see 'slv vault writer').`,
+			Run: func(cmd *cobra.Command, args []string) {
+				vaultFile := cmd.Flag(vaultFileFlag.Name).Value.String()
+				vault, err := vaults.Get(vaultFile)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				writers := helpers.GetVaultWriters(vault)
+				result := vault.Verify(helpers.GetSigningKeys(writers))
+				showVerifyResult(result, writers)
+				if !result.Verified() {
+					fmt.Println(color.RedString("Vault %s could not be verified", vaultFile))
+					utils.ErroredExit()
+				}
+				fmt.Println(color.GreenString("Vault %s is verified", vaultFile))
+				utils.SafeExit()
+			},
+		}
+	}
+	return vaultVerifyCmd
+}
+
+func vaultSignCommand() *cobra.Command {
+	if vaultSignCmd == nil {
+		vaultSignCmd = &cobra.Command{
+			Use:   "sign",
+			Short: "Signs the current contents of the vault with the session environment",
+			Long: `Signs the wrapped keys, every item and every file key of the vault with the signing key of the session environment.
+Use this to adopt vaults created before signing was introduced; signing vouches for the current contents of the vault.`,
+			Run: func(cmd *cobra.Command, args []string) {
+				vaultFile := cmd.Flag(vaultFileFlag.Name).Value.String()
+				vault, err := vaults.Get(vaultFile)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				if !setVaultSigner(vault) {
+					utils.ExitOnErrorWithMessage("the vault is not accessible by the current session")
+				}
+				if err = vault.Sign(); err != nil {
+					utils.ExitOnError(err)
+				}
+				fmt.Println("Signed vault:", color.GreenString(vaultFile))
+				utils.SafeExit()
+			},
+		}
+	}
+	return vaultSignCmd
+}
+
+func signerName(check vaults.SignatureCheck, writers []*helpers.VaultWriter) string {
+	for _, writer := range writers {
+		if writer.SigningKey.Equals(check.Signer) {
+			return writerName(writer)
+		}
+	}
+	return check.KeyId
+}
+
+func signatureStatusString(status vaults.SignatureStatus) string {
+	switch status {
+	case vaults.SignatureValid:
+		return color.GreenString(string(status))
+	case vaults.SignatureMissing:
+		return color.YellowString(string(status))
+	default:
+		return color.RedString(string(status))
+	}
+}
+
+func showVerifyResult(result *vaults.VerifyResult, writers []*helpers.VaultWriter) {
+	resultTable := table.NewWriter()
+	resultTable.SetOutputMirror(os.Stdout)
+	resultTable.AppendHeader(table.Row{
+		text.Colors{text.Bold}.Sprint("Entry"),
+		text.Colors{text.Bold}.Sprint("Signature"),
+		text.Colors{text.Bold}.Sprint("Signed By"),
+	})
+	resultTable.AppendRow(table.Row{"(wrapped keys)", signatureStatusString(result.Keys.Status), signerName(result.Keys, writers)})
+	names := make([]string, 0, len(result.Items))
+	for name := range result.Items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		check := result.Items[name]
+		resultTable.AppendRow(table.Row{name, signatureStatusString(check.Status), signerName(check, writers)})
+	}
+	fileIds := make([]string, 0, len(result.Files))
+	for fileId := range result.Files {
+		fileIds = append(fileIds, fileId)
+	}
+	sort.Strings(fileIds)
+	for _, fileId := range fileIds {
+		check := result.Files[fileId]
+		resultTable.AppendRow(table.Row{"(file " + fileId + ")", signatureStatusString(check.Status), signerName(check, writers)})
+	}
+	resultTable.SetStyle(table.StyleLight)
+	resultTable.Render()
+}
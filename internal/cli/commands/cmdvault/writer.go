@@ -0,0 +1,164 @@
+package cmdvault
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/utils"
+	"slv.sh/slv/internal/core/crypto"
+	"slv.sh/slv/internal/core/profiles"
+	"slv.sh/slv/internal/core/session"
+	"slv.sh/slv/internal/core/vaults"
+	"slv.sh/slv/internal/helpers"
+)
+
+func vaultWriterCommand() *cobra.Command {
+	if vaultWriterCmd == nil {
+		vaultWriterCmd = &cobra.Command{
+			Use:     "writer",
+			Aliases: []string{"writers"},
+			Short:   "Lists the environments authorized to sign changes to the vault",
+			Long: `Lists the environments authorized to sign changes to the vault. The root and the admins of the active profile
+are always authorized (or the session environment for profiles that do not sign their environments). Other
+environments are authorized by being listed as writers in the vault by an authorized writer.`,
+			Run: func(cmd *cobra.Command, args []string) {
+				vaultFile := cmd.Flag(vaultFileFlag.Name).Value.String()
+				vault, err := vaults.Get(vaultFile)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				showVaultWriters(helpers.GetVaultWriters(vault), vault.ListWriters())
+			},
+		}
+		vaultWriterCmd.AddCommand(vaultWriterAddCommand())
+		vaultWriterCmd.AddCommand(vaultWriterRemoveCommand())
+	}
+	return vaultWriterCmd
+}
+
+func vaultWriterAddCommand() *cobra.Command {
+	if vaultWriterAddCmd == nil {
+		vaultWriterAddCmd = &cobra.Command{
+			Use:   "add <signing key, public key or fingerprint>",
+			Short: "Authorizes an environment to sign changes to the vault, as an authorized writer",
+			Args:  cobra.ExactArgs(1),
+			Run: func(cmd *cobra.Command, args []string) {
+				vaultFile := cmd.Flag(vaultFileFlag.Name).Value.String()
+				vault, err := vaults.Get(vaultFile)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				signingKey, err := getWriterSigningKey(args[0])
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				secretKeys, err := session.GetSecretKeys()
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				if err = helpers.AddVaultWriter(vault, signingKey, secretKeys); err != nil {
+					utils.ExitOnError(err)
+				}
+				fmt.Println("Added vault writer:", color.GreenString(args[0]))
+			},
+		}
+	}
+	return vaultWriterAddCmd
+}
+
+func vaultWriterRemoveCommand() *cobra.Command {
+	if vaultWriterRemoveCmd == nil {
+		vaultWriterRemoveCmd = &cobra.Command{
+			Use:     "rm <signing key, public key or fingerprint>",
+			Aliases: []string{"remove", "delete", "del"},
+			Short:   "Withdraws an environment from the writers of the vault",
+			Args:    cobra.ExactArgs(1),
+			Run: func(cmd *cobra.Command, args []string) {
+				vaultFile := cmd.Flag(vaultFileFlag.Name).Value.String()
+				vault, err := vaults.Get(vaultFile)
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				signingKey, err := getWriterSigningKey(args[0])
+				if err != nil {
+					utils.ExitOnError(err)
+				}
+				if err = vault.RemoveWriter(signingKey); err != nil {
+					utils.ExitOnError(err)
+				}
+				fmt.Println("Removed vault writer:", color.GreenString(args[0]))
+			},
+		}
+	}
+	return vaultWriterRemoveCmd
+}
+
+// getWriterSigningKey returns the signing key given as such, or that of the trusted environment of the active profile
+// with the given public key or fingerprint.
+func getWriterSigningKey(signingKeyOrEnv string) (*crypto.VerificationKey, error) {
+	if signingKey, err := crypto.VerificationKeyFromString(signingKeyOrEnv); err == nil {
+		return signingKey, nil
+	}
+	profile, err := profiles.GetActiveProfile()
+	if err != nil {
+		return nil, err
+	}
+	env, err := profile.GetEnv(signingKeyOrEnv)
+	if err == nil && env == nil {
+		env, _ = profile.GetEnvByFingerprint(signingKeyOrEnv)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if env == nil {
+		return nil, fmt.Errorf("no environment found in profile %s for %s", profile.Name(), signingKeyOrEnv)
+	}
+	if err = profile.VerifyEnv(env); err != nil {
+		return nil, fmt.Errorf("refusing environment %s (%s): %w", env.Name, env.PublicKey, err)
+	}
+	return env.GetSigningKey()
+}
+
+func writerName(writer *helpers.VaultWriter) string {
+	switch {
+	case writer.Env == nil:
+		return writer.SigningKey.KeyId()
+	case writer.Env.Name != "":
+		return writer.Env.Name
+	default:
+		return writer.Env.PublicKey
+	}
+}
+
+func showVaultWriters(writers []*helpers.VaultWriter, listedWriters []*crypto.VerificationKey) {
+	writersTable := table.NewWriter()
+	writersTable.SetOutputMirror(os.Stdout)
+	writersTable.AppendHeader(table.Row{
+		text.Colors{text.Bold}.Sprint("Writer"),
+		text.Colors{text.Bold}.Sprint("Key Id"),
+		text.Colors{text.Bold}.Sprint("Status"),
+	})
+	for _, writer := range writers {
+		status := color.CyanString("trusted")
+		if slices.ContainsFunc(listedWriters, writer.SigningKey.Equals) {
+			status = color.GreenString("listed")
+		}
+		name := ""
+		if writer.Env != nil {
+			name = writerName(writer)
+		}
+		writersTable.AppendRow(table.Row{name, writer.SigningKey.KeyId(), status})
+	}
+	for _, listedWriter := range listedWriters {
+		if !slices.ContainsFunc(writers, func(writer *helpers.VaultWriter) bool { return writer.SigningKey.Equals(listedWriter) }) {
+			writersTable.AppendRow(table.Row{"", listedWriter.KeyId(), color.RedString("not authorized")})
+		}
+	}
+	writersTable.SetStyle(table.StyleLight)
+	writersTable.Render()
+}
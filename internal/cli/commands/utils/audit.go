@@ -0,0 +1,15 @@
+package utils
+
+import (
+	"fmt"
+
+	"slv.sh/slv/internal/core/audit"
+)
+
+// AuditLog records the given entry in the audit log (if enabled) and exits if it cannot be recorded. It is to be
+// called before the operation is carried out, so that a failed write aborts the operation.
+func AuditLog(entry *audit.Entry) {
+	if err := audit.Record(audit.CLI, entry); err != nil {
+		ExitOnError(fmt.Errorf("error writing audit log: %w", err))
+	}
+}
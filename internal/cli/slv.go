@@ -4,12 +4,17 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"slv.sh/slv/internal/cli/commands/cmdagent"
+	"slv.sh/slv/internal/cli/commands/cmdaudit"
 	"slv.sh/slv/internal/cli/commands/cmdenv"
+	"slv.sh/slv/internal/cli/commands/cmdfile"
 	"slv.sh/slv/internal/cli/commands/cmdprofile"
+	"slv.sh/slv/internal/cli/commands/cmdreport"
 	"slv.sh/slv/internal/cli/commands/cmdsystem"
 	"slv.sh/slv/internal/cli/commands/cmdvault"
 	"slv.sh/slv/internal/cli/commands/utils"
 	"slv.sh/slv/internal/core/config"
+	"slv.sh/slv/internal/core/session"
 )
 
 var (
@@ -23,6 +28,11 @@ var (
 		Shorthand: "v",
 		Usage:     "Shows version info",
 	}
+
+	asFlag = utils.FlagDef{
+		Name:  "as",
+		Usage: "Acts as the given identity of the session (environment name, public key or fingerprint)",
+	}
 )
 
 func slvCommand() *cobra.Command {
@@ -38,13 +48,23 @@ func slvCommand() *cobra.Command {
 					cmd.Help()
 				}
 			},
+			PersistentPreRun: func(cmd *cobra.Command, args []string) {
+				if as, _ := cmd.Flags().GetString(asFlag.Name); as != "" {
+					session.SetIdentity(as)
+				}
+			},
 		}
 		slvCmd.Flags().BoolP(versionFlag.Name, versionFlag.Shorthand, false, versionFlag.Usage)
+		slvCmd.PersistentFlags().String(asFlag.Name, "", asFlag.Usage)
 		slvCmd.AddCommand(versionCommand())
 		slvCmd.AddCommand(cmdsystem.SystemCommand())
 		slvCmd.AddCommand(cmdenv.EnvCommand())
 		slvCmd.AddCommand(cmdprofile.ProfileCommand())
 		slvCmd.AddCommand(cmdvault.VaultCommand())
+		slvCmd.AddCommand(cmdfile.FileCommand())
+		slvCmd.AddCommand(cmdreport.ReportCommand())
+		slvCmd.AddCommand(cmdaudit.AuditCommand())
+		slvCmd.AddCommand(cmdagent.AgentCommand())
 		slvCmd.AddCommand(webCommand())
 		slvCmd.AddCommand(tuiCommand())
 	}
@@ -0,0 +1,57 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"slv.sh/slv/internal/core/config"
+)
+
+// request is written as a line of JSON to the agent socket. Byte values are base64 encoded.
+type request struct {
+	Operation   string `json:"op"`
+	Data        []byte `json:"data,omitempty"`
+	PostQuantum bool   `json:"pq,omitempty"`
+}
+
+// response is written back as a line of JSON for every request. A non empty Error fails the request.
+type response struct {
+	Error           string     `json:"error,omitempty"`
+	PublicKeyEC     string     `json:"publicKeyEC,omitempty"`
+	PublicKeyPQ     string     `json:"publicKeyPQ,omitempty"`
+	ExpiresAt       *time.Time `json:"expiresAt,omitempty"`
+	Data            []byte     `json:"data,omitempty"`
+	VerificationKey string     `json:"verificationKey,omitempty"`
+	Signature       string     `json:"signature,omitempty"`
+}
+
+// Status describes the secret key held by a running agent.
+type Status struct {
+	PublicKeyEC string
+	PublicKeyPQ string
+	ExpiresAt   *time.Time
+}
+
+// GetSocketPath returns the socket of the agent given by SLV_AGENT_SOCK, or an empty string if not set.
+func GetSocketPath() string {
+	return os.Getenv(envar_SLV_AGENT_SOCK)
+}
+
+// DefaultSocketPath returns the socket an agent listens on when SLV_AGENT_SOCK is not set.
+func DefaultSocketPath() string {
+	if socketPath := GetSocketPath(); socketPath != "" {
+		return socketPath
+	}
+	return filepath.Join(config.GetAppDataDir(), socketFileName)
+}
+
+// ExportCommand returns the shell command that points later commands at the agent listening on the given socket.
+func ExportCommand(socketPath string) string {
+	return envar_SLV_AGENT_SOCK + "=" + socketPath + "; export " + envar_SLV_AGENT_SOCK + ";"
+}
+
+// Unsetenv stops the current process from using an agent, as when the agent itself unlocks the secret key.
+func Unsetenv() error {
+	return os.Unsetenv(envar_SLV_AGENT_SOCK)
+}
@@ -0,0 +1,106 @@
+package agent
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"time"
+
+	"slv.sh/slv/internal/core/crypto"
+)
+
+// client performs the operations of a secret key by sending them to the agent, implementing crypto.RemoteKey.
+type client struct {
+	socketPath string
+}
+
+func (c *client) call(req *request) (*response, error) {
+	conn, err := net.DialTimeout("unix", c.socketPath, time.Second)
+	if err != nil {
+		return nil, errAgentNotRunning
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(requestTimeout))
+	if err = json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, err
+	}
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		return nil, err
+	}
+	resp := new(response)
+	if err = json.Unmarshal(line, resp); err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+	return resp, nil
+}
+
+func (c *client) DecryptWrappedKey(wrapped []byte) ([]byte, error) {
+	resp, err := c.call(&request{Operation: operationDecrypt, Data: wrapped})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+func (c *client) VerificationKey(postQuantum bool) (*crypto.VerificationKey, error) {
+	resp, err := c.call(&request{Operation: operationVerificationKey, PostQuantum: postQuantum})
+	if err != nil {
+		return nil, err
+	}
+	return crypto.VerificationKeyFromString(resp.VerificationKey)
+}
+
+func (c *client) Sign(data []byte, postQuantum bool) (*crypto.Signature, error) {
+	resp, err := c.call(&request{Operation: operationSign, Data: data, PostQuantum: postQuantum})
+	if err != nil {
+		return nil, err
+	}
+	return crypto.SignatureFromString(resp.Signature)
+}
+
+// GetStatus returns the status of the agent listening on the given socket.
+func GetStatus(socketPath string) (*Status, error) {
+	resp, err := (&client{socketPath: socketPath}).call(&request{Operation: operationKeys})
+	if err != nil {
+		return nil, err
+	}
+	return &Status{
+		PublicKeyEC: resp.PublicKeyEC,
+		PublicKeyPQ: resp.PublicKeyPQ,
+		ExpiresAt:   resp.ExpiresAt,
+	}, nil
+}
+
+// Stop makes the agent listening on the given socket wipe its secret key and exit.
+func Stop(socketPath string) error {
+	_, err := (&client{socketPath: socketPath}).call(&request{Operation: operationStop})
+	return err
+}
+
+// GetSecretKey returns a secret key whose operations are performed by the agent given by SLV_AGENT_SOCK.
+// It returns nil if SLV_AGENT_SOCK is not set.
+func GetSecretKey() (*crypto.SecretKey, error) {
+	socketPath := GetSocketPath()
+	if socketPath == "" {
+		return nil, nil
+	}
+	status, err := GetStatus(socketPath)
+	if err != nil {
+		return nil, err
+	}
+	publicKeyEC, err := crypto.PublicKeyFromString(status.PublicKeyEC)
+	if err != nil {
+		return nil, err
+	}
+	publicKeyPQ, err := crypto.PublicKeyFromString(status.PublicKeyPQ)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.NewRemoteSecretKey(publicKeyEC, publicKeyPQ, &client{socketPath: socketPath})
+}
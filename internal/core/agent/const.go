@@ -0,0 +1,27 @@
+package agent
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	envar_SLV_AGENT_SOCK = "SLV_AGENT_SOCK"
+
+	socketFileName = "agent.sock"
+	requestTimeout = 30 * time.Second
+
+	operationKeys            = "keys"
+	operationDecrypt         = "decrypt"
+	operationVerificationKey = "verification-key"
+	operationSign            = "sign"
+	operationStop            = "stop"
+)
+
+var (
+	errAgentRunning     = errors.New("an agent is already listening on the socket")
+	errAgentKeyExpired  = errors.New("the agent no longer holds the secret key")
+	errAgentNotRunning  = errors.New("no agent is listening on the socket")
+	errPeerNotPermitted = errors.New("connection from another user refused")
+	errUnknownOperation = errors.New("unknown agent operation")
+)
@@ -0,0 +1,36 @@
+//go:build linux
+
+package agent
+
+import (
+	"net"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// checkPeer refuses connections from processes of other users, as ssh-agent does. Root is let through.
+func checkPeer(conn net.Conn) error {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return errPeerNotPermitted
+	}
+	rawConn, err := unixConn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var ucred *unix.Ucred
+	var credErr error
+	if err = rawConn.Control(func(fd uintptr) {
+		ucred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	}); err != nil {
+		return err
+	}
+	if credErr != nil {
+		return credErr
+	}
+	if ucred.Uid != 0 && int(ucred.Uid) != os.Getuid() {
+		return errPeerNotPermitted
+	}
+	return nil
+}
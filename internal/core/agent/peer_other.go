@@ -0,0 +1,10 @@
+//go:build !linux
+
+package agent
+
+import "net"
+
+// checkPeer relies on the permissions of the socket, which only the current user can connect to.
+func checkPeer(conn net.Conn) error {
+	return nil
+}
@@ -0,0 +1,22 @@
+//go:build !unix
+
+package agent
+
+import (
+	"net"
+	"os"
+)
+
+func disableCoreDumps() {}
+
+func listenSocket(socketPath string) (net.Listener, error) {
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.Chmod(socketPath, 0600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
@@ -0,0 +1,22 @@
+//go:build unix
+
+package agent
+
+import (
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+// disableCoreDumps keeps the secret key out of core dumps of the agent.
+func disableCoreDumps() {
+	unix.Setrlimit(unix.RLIMIT_CORE, &unix.Rlimit{})
+}
+
+// listenSocket creates the socket with permissions for the current user alone from the start, rather than
+// restricting them once others may already have connected.
+func listenSocket(socketPath string) (net.Listener, error) {
+	umask := unix.Umask(0177)
+	defer unix.Umask(umask)
+	return net.Listen("unix", socketPath)
+}
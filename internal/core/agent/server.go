@@ -0,0 +1,180 @@
+package agent
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"slv.sh/slv/internal/core/crypto"
+)
+
+// Server holds an unlocked secret key and performs operations with it for clients connecting to its socket. The
+// secret key itself is never sent to the clients. It is held on the Go heap, which can neither be locked into RAM
+// nor reliably wiped, so it may be written to swap; core dumps of the agent are disabled.
+type Server struct {
+	socketPath  string
+	secretKey   *crypto.SecretKey
+	publicKeyEC string
+	publicKeyPQ string
+	expiresAt   *time.Time
+	listener    net.Listener
+	mutex       sync.Mutex
+	closed      bool
+}
+
+// NewServer holds the secret key and listens on the given socket, which only the current user can connect to.
+// A zero ttl keeps the secret key until the server is stopped.
+func NewServer(secretKey *crypto.SecretKey, socketPath string, ttl time.Duration) (*Server, error) {
+	server := &Server{socketPath: socketPath, secretKey: secretKey}
+	for _, pq := range []bool{false, true} {
+		publicKey, err := secretKey.PublicKey(pq)
+		if err != nil {
+			return nil, err
+		}
+		publicKeyStr, err := publicKey.String()
+		if err != nil {
+			return nil, err
+		}
+		if pq {
+			server.publicKeyPQ = publicKeyStr
+		} else {
+			server.publicKeyEC = publicKeyStr
+		}
+	}
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		server.expiresAt = &expiresAt
+	}
+	if err := server.listen(); err != nil {
+		return nil, err
+	}
+	disableCoreDumps()
+	return server, nil
+}
+
+func (server *Server) listen() error {
+	if err := os.MkdirAll(filepath.Dir(server.socketPath), 0700); err != nil {
+		return err
+	}
+	if _, err := os.Stat(server.socketPath); err == nil {
+		if conn, err := net.DialTimeout("unix", server.socketPath, time.Second); err == nil {
+			conn.Close()
+			return errAgentRunning
+		}
+		if err = os.Remove(server.socketPath); err != nil {
+			return err
+		}
+	}
+	listener, err := listenSocket(server.socketPath)
+	if err != nil {
+		return err
+	}
+	server.listener = listener
+	return nil
+}
+
+// Serve accepts connections until the secret key expires or the server is stopped.
+func (server *Server) Serve() error {
+	if server.expiresAt != nil {
+		timer := time.AfterFunc(time.Until(*server.expiresAt), func() {
+			server.Close()
+		})
+		defer timer.Stop()
+	}
+	for {
+		conn, err := server.listener.Accept()
+		if err != nil {
+			if server.isClosed() {
+				return nil
+			}
+			server.Close()
+			return err
+		}
+		go server.handleConn(conn)
+	}
+}
+
+func (server *Server) isClosed() bool {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return server.closed
+}
+
+// Close stops listening, removes the socket and drops the secret key.
+func (server *Server) Close() error {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	if server.closed {
+		return nil
+	}
+	server.closed = true
+	server.secretKey = nil
+	err := server.listener.Close()
+	os.Remove(server.socketPath)
+	return err
+}
+
+func (server *Server) handleConn(conn net.Conn) {
+	defer conn.Close()
+	if err := checkPeer(conn); err != nil {
+		json.NewEncoder(conn).Encode(&response{Error: err.Error()})
+		return
+	}
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	encoder := json.NewEncoder(conn)
+	for scanner.Scan() {
+		req := new(request)
+		resp := new(response)
+		if err := json.Unmarshal(scanner.Bytes(), req); err != nil {
+			resp.Error = err.Error()
+		} else if err = server.handle(req, resp); err != nil {
+			resp.Error = err.Error()
+		}
+		if err := encoder.Encode(resp); err != nil {
+			return
+		}
+		if req.Operation == operationStop && resp.Error == "" {
+			server.Close()
+			return
+		}
+	}
+}
+
+func (server *Server) handle(req *request, resp *response) error {
+	if req.Operation == operationKeys || req.Operation == operationStop {
+		resp.PublicKeyEC = server.publicKeyEC
+		resp.PublicKeyPQ = server.publicKeyPQ
+		resp.ExpiresAt = server.expiresAt
+		return nil
+	}
+	server.mutex.Lock()
+	if server.closed {
+		server.mutex.Unlock()
+		return errAgentKeyExpired
+	}
+	secretKey := server.secretKey
+	server.mutex.Unlock()
+	var err error
+	switch req.Operation {
+	case operationDecrypt:
+		resp.Data, err = secretKey.DecryptWrappedKey(req.Data)
+	case operationVerificationKey:
+		var verificationKey *crypto.VerificationKey
+		if verificationKey, err = secretKey.VerificationKey(req.PostQuantum); err == nil {
+			resp.VerificationKey = verificationKey.String()
+		}
+	case operationSign:
+		var signature *crypto.Signature
+		if signature, err = secretKey.Sign(req.Data, req.PostQuantum); err == nil {
+			resp.Signature = signature.String()
+		}
+	default:
+		err = errUnknownOperation
+	}
+	return err
+}
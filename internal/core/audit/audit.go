@@ -0,0 +1,172 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"path/filepath"
+	"time"
+
+	"slv.sh/slv/internal/core/commons"
+	"slv.sh/slv/internal/core/config"
+	"slv.sh/slv/internal/core/crypto"
+	"slv.sh/slv/internal/core/profiles"
+	"slv.sh/slv/internal/core/session"
+)
+
+// Entry is a single record in the audit log. Every entry carries the hash of the previous entry,
+// forming a hash chain, and is signed with the signing key of the session environment.
+// Entries are recorded before the operation is carried out, so that no operation takes place unrecorded.
+type Entry struct {
+	Seq      uint64    `json:"seq"`
+	Time     time.Time `json:"time"`
+	Source   Source    `json:"source"`
+	Action   Action    `json:"action"`
+	Vault    string    `json:"vault,omitempty"`
+	Items    []string  `json:"items,omitempty"`
+	Envs     []string  `json:"envs,omitempty"`
+	File     string    `json:"file,omitempty"`
+	Actor    string    `json:"actor,omitempty"`
+	Signer   string    `json:"signer,omitempty"`
+	PrevHash string    `json:"prevHash"`
+	Hash     string    `json:"hash"`
+	Sig      string    `json:"sig,omitempty"`
+}
+
+type auditConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+}
+
+func getAuditDir() string {
+	return filepath.Join(config.GetAppDataDir(), auditDirName)
+}
+
+// LogFile returns the path of the audit log file.
+func LogFile() string {
+	return filepath.Join(getAuditDir(), auditLogFileName)
+}
+
+func getConfig() *auditConfig {
+	cfg := &auditConfig{}
+	if configFile := filepath.Join(getAuditDir(), auditConfigFileName); commons.FileExists(configFile) {
+		commons.ReadFromYAML(configFile, cfg)
+	}
+	return cfg
+}
+
+// IsRequired reports whether the active profile makes the audit log mandatory.
+func IsRequired() bool {
+	if profile, err := profiles.GetActiveProfile(); err == nil {
+		if settings, err := profile.GetSettings(); err == nil {
+			return settings.AuditRequired
+		}
+	}
+	return false
+}
+
+// IsEnabled reports whether audit logging is enabled locally or required by the active profile.
+func IsEnabled() bool {
+	return getConfig().Enabled || IsRequired()
+}
+
+// SetEnabled opts in or out of audit logging on this machine.
+func SetEnabled(enabled bool) error {
+	if !enabled && IsRequired() {
+		return errAuditRequired
+	}
+	if err := mkdirAuditDir(); err != nil {
+		return err
+	}
+	return commons.WriteToYAML(filepath.Join(getAuditDir(), auditConfigFileName), &auditConfig{Enabled: enabled})
+}
+
+// Record appends an entry to the audit log when auditing is enabled. The sequence number, time, actor,
+// hash chain and signature are filled in by Record.
+func Record(source Source, entry *Entry) error {
+	if !IsEnabled() {
+		return nil
+	}
+	var secretKey *crypto.SecretKey
+	var pq bool
+	if sess, err := session.GetSession(); err == nil && sess.SecretKey() != nil {
+		secretKey = sess.SecretKey()
+		entry.Actor = sess.PublicKeyEC()
+		// Signed with the same signing key the profile records for the environment, so that others can verify it
+		if env, err := sess.Env(); err == nil && env != nil {
+			if signingKey, err := env.GetSigningKey(); err == nil {
+				pq = signingKey.Algorithm() == crypto.MLDSA65
+			}
+		}
+	} else if IsRequired() {
+		return errAuditNoSession
+	}
+	if entry.Vault != "" {
+		if absPath, err := filepath.Abs(entry.Vault); err == nil {
+			entry.Vault = absPath
+		}
+	}
+	entry.Source = source
+	entry.Time = time.Now().UTC()
+	return appendEntry(entry, secretKey, pq)
+}
+
+func (entry *Entry) computeHash() (string, error) {
+	unsealed := *entry
+	unsealed.Hash = ""
+	unsealed.Sig = ""
+	data, err := json.Marshal(&unsealed)
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256(append([]byte(entry.PrevHash), data...))
+	return hex.EncodeToString(digest[:]), nil
+}
+
+func signingData(hash string) []byte {
+	return []byte(entrySigningContext + "\n" + hash)
+}
+
+// verifySignature reports whether the entry is signed by the signing key it names.
+func (entry *Entry) verifySignature() (*crypto.VerificationKey, bool) {
+	signer, err := crypto.VerificationKeyFromString(entry.Signer)
+	if err != nil {
+		return nil, false
+	}
+	signature, err := crypto.SignatureFromString(entry.Sig)
+	if err != nil || !signature.IsSignedBy(signer) {
+		return nil, false
+	}
+	return signer, signer.Verify(signingData(entry.Hash), signature)
+}
+
+// TrustedSigners returns the signing keys of the environments the active profile trusts, along with those of the
+// given secret keys, which are the keys audit log entries are verified against.
+func TrustedSigners(secretKeys ...*crypto.SecretKey) []*crypto.VerificationKey {
+	var signers []*crypto.VerificationKey
+	for _, secretKey := range secretKeys {
+		for _, pq := range []bool{false, true} {
+			if secretKey != nil {
+				if signer, err := secretKey.VerificationKey(pq); err == nil {
+					signers = append(signers, signer)
+				}
+			}
+		}
+	}
+	profile, err := profiles.GetActiveProfile()
+	if err != nil {
+		return signers
+	}
+	envs, _ := profile.ListEnvs()
+	if root, err := profile.GetRoot(); err == nil && root != nil {
+		envs = append(envs, root)
+	}
+	for _, env := range envs {
+		if profile.VerifyEnv(env) != nil {
+			continue
+		}
+		if signer, err := env.GetSigningKey(); err == nil {
+			signers = append(signers, signer)
+		}
+	}
+	return signers
+}
@@ -0,0 +1,49 @@
+package audit
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+type Source string
+
+type Action string
+
+const (
+	CLI Source = "cli"
+	API Source = "api"
+	TUI Source = "tui"
+
+	Put     Action = "put"
+	Delete  Action = "delete"
+	Get     Action = "get"
+	Unlock  Action = "unlock"
+	Grant   Action = "grant"
+	Revoke  Action = "revoke"
+	Ref     Action = "ref"
+	Deref   Action = "deref"
+	Upgrade Action = "upgrade"
+	Encrypt Action = "encrypt"
+	Decrypt Action = "decrypt"
+
+	auditDirName        = "audit"
+	auditLogFileName    = "audit.log"
+	auditConfigFileName = "config.yaml"
+	auditLockTimeout    = 10 * time.Second
+	auditTailReadSize   = 64 * 1024
+
+	entrySigningContext = "slv-audit-entry"
+)
+
+var (
+	auditMutex sync.Mutex
+
+	errAuditRequired      = errors.New("audit log is mandatory for the active profile and cannot be disabled")
+	errAuditNoSession     = errors.New("audit log is mandatory for the active profile but no environment session is available to sign entries")
+	errAuditChainBroken   = errors.New("audit log hash chain is broken")
+	errAuditHashMismatch  = errors.New("audit log entry hash does not match its contents")
+	errAuditSigMismatch   = errors.New("audit log entry signature is invalid")
+	errAuditSeqMismatch   = errors.New("audit log entry sequence is out of order")
+	errAuditInvalidFormat = errors.New("invalid audit log entry")
+)
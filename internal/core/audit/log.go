@@ -0,0 +1,201 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"slv.sh/slv/internal/core/commons"
+	"slv.sh/slv/internal/core/crypto"
+)
+
+// VerifyResult summarizes the outcome of verifying the audit log. Untrusted counts the entries with a valid signature
+// by a key that belongs to none of the trusted signers. Unverifiable counts the untrusted entries and the unsigned
+// ones after the first signed entry, which a strict verification rejects: anyone who can write to the log can sign
+// with a key of their own, and once entries are signed, an unsigned one may have been forged.
+type VerifyResult struct {
+	Entries      int `json:"entries"`
+	Verified     int `json:"verified"`
+	Unsigned     int `json:"unsigned"`
+	Untrusted    int `json:"untrusted"`
+	Unverifiable int `json:"unverifiable"`
+}
+
+func mkdirAuditDir() error {
+	if !commons.DirExists(getAuditDir()) {
+		if err := os.MkdirAll(getAuditDir(), 0700); err != nil {
+			return fmt.Errorf("error creating audit directory: %w", err)
+		}
+	}
+	return nil
+}
+
+func appendEntry(entry *Entry, secretKey *crypto.SecretKey, pq bool) (err error) {
+	auditMutex.Lock()
+	defer auditMutex.Unlock()
+	if err = mkdirAuditDir(); err != nil {
+		return err
+	}
+	unlock, err := commons.LockFile(LogFile(), auditLockTimeout)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	lastEntry, err := readLastEntry()
+	if err != nil {
+		return err
+	}
+	if lastEntry != nil {
+		entry.Seq = lastEntry.Seq + 1
+		entry.PrevHash = lastEntry.Hash
+	} else {
+		entry.Seq = 1
+		entry.PrevHash = ""
+	}
+	if secretKey != nil {
+		var signer *crypto.VerificationKey
+		if signer, err = secretKey.VerificationKey(pq); err != nil {
+			return err
+		}
+		entry.Signer = signer.String()
+	}
+	if entry.Hash, err = entry.computeHash(); err != nil {
+		return err
+	}
+	if secretKey != nil {
+		var signature *crypto.Signature
+		if signature, err = secretKey.Sign(signingData(entry.Hash), pq); err != nil {
+			return err
+		}
+		entry.Sig = signature.String()
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	logFile, err := os.OpenFile(LogFile(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("error opening audit log: %w", err)
+	}
+	defer logFile.Close()
+	if _, err = logFile.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("error writing audit log: %w", err)
+	}
+	return logFile.Sync()
+}
+
+func readLastEntry() (*Entry, error) {
+	logFile, err := os.Open(LogFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer logFile.Close()
+	info, err := logFile.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := info.Size()
+	for readSize := int64(auditTailReadSize); ; readSize *= 2 {
+		if readSize > size {
+			readSize = size
+		}
+		buf := make([]byte, readSize)
+		if _, err = logFile.ReadAt(buf, size-readSize); err != nil && err != io.EOF {
+			return nil, err
+		}
+		buf = bytes.TrimRight(buf, "\n")
+		if len(buf) == 0 {
+			return nil, nil
+		}
+		if idx := bytes.LastIndexByte(buf, '\n'); idx >= 0 || readSize == size {
+			entry := &Entry{}
+			if err = json.Unmarshal(buf[idx+1:], entry); err != nil {
+				return nil, errAuditInvalidFormat
+			}
+			return entry, nil
+		}
+	}
+}
+
+// ReadEntries returns all entries in the audit log in the order they were recorded.
+func ReadEntries() ([]*Entry, error) {
+	logFile, err := os.Open(LogFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer logFile.Close()
+	var entries []*Entry
+	scanner := bufio.NewScanner(logFile)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		entry := &Entry{}
+		if err = json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, errAuditInvalidFormat)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// Verify checks the hash chain of the audit log and the signatures of its entries. Every signature is checked against
+// the signing key the entry names, and counts as verified only if that key belongs to one of the trusted signers,
+// such as the environments of the active profile.
+func Verify(trustedSigners []*crypto.VerificationKey) (*VerifyResult, error) {
+	entries, err := ReadEntries()
+	if err != nil {
+		return nil, err
+	}
+	result := &VerifyResult{}
+	prevHash := ""
+	signed := false
+	for i, entry := range entries {
+		if entry.Seq != uint64(i+1) {
+			return result, fmt.Errorf("entry %d: %w", entry.Seq, errAuditSeqMismatch)
+		}
+		if entry.PrevHash != prevHash {
+			return result, fmt.Errorf("entry %d: %w", entry.Seq, errAuditChainBroken)
+		}
+		hash, err := entry.computeHash()
+		if err != nil {
+			return result, err
+		}
+		if hash != entry.Hash {
+			return result, fmt.Errorf("entry %d: %w", entry.Seq, errAuditHashMismatch)
+		}
+		// Entries of earlier releases carry an HMAC without a signer, which cannot be checked by anyone else
+		if entry.Sig == "" || entry.Signer == "" {
+			result.Unsigned++
+			if signed {
+				result.Unverifiable++
+			}
+		} else {
+			signer, valid := entry.verifySignature()
+			if !valid {
+				return result, fmt.Errorf("entry %d: %w", entry.Seq, errAuditSigMismatch)
+			}
+			if slices.ContainsFunc(trustedSigners, signer.Equals) {
+				result.Verified++
+			} else {
+				result.Untrusted++
+				result.Unverifiable++
+			}
+			signed = true
+		}
+		result.Entries++
+		prevHash = entry.Hash
+	}
+	return result, nil
+}
@@ -2,6 +2,7 @@ package commons
 
 import (
 	"os"
+	"path/filepath"
 
 	"gopkg.in/yaml.v3"
 )
@@ -24,3 +25,28 @@ func ReadFromYAML(filePath string, out any) error {
 	}
 	return err
 }
+
+// WriteFileAtomic replaces the file in one step by renaming a fully written temporary file over it, so that
+// readers, including those on shared (network) file systems, never see it partially written.
+func WriteFileAtomic(filePath string, data []byte, perm os.FileMode) (err error) {
+	tempFile, err := os.CreateTemp(filepath.Dir(filePath), "."+filepath.Base(filePath)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(tempFile.Name())
+		}
+	}()
+	_, err = tempFile.Write(data)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tempFile.Name(), perm)
+	}
+	if err == nil {
+		err = os.Rename(tempFile.Name(), filePath)
+	}
+	return err
+}
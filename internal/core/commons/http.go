@@ -1,15 +1,28 @@
 package commons
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 )
 
+// ErrPreconditionFailed is returned when the contents at a URL have changed since the ETag given to
+// PutURLContents was read.
+var ErrPreconditionFailed = errors.New("precondition failed")
+
 func GetURLContents(url string, headers map[string]string) ([]byte, error) {
+	bodyBytes, _, err := GetURLContentsWithETag(url, headers)
+	return bodyBytes, err
+}
+
+// GetURLContentsWithETag returns the contents at the URL along with their ETag, which is empty if the server
+// sends none. Contents that are not found are returned as nil.
+func GetURLContentsWithETag(url string, headers map[string]string) ([]byte, string, error) {
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
 	}
 	for key, value := range headers {
 		req.Header.Set(key, value)
@@ -17,18 +30,53 @@ func GetURLContents(url string, headers map[string]string) ([]byte, error) {
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, "", fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, "", fmt.Errorf("failed to read response body: %w", err)
 	}
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, nil
+		return nil, "", nil
+	}
+	return bodyBytes, resp.Header.Get("ETag"), nil
+}
+
+// PutURLContents uploads the data to the URL with PUT and returns the new ETag sent by the server, if any.
+// The upload only succeeds if the contents at the URL still match the given ETag; "*" requires that there are
+// no contents yet and an empty ETag uploads unconditionally. ErrPreconditionFailed is returned otherwise.
+func PutURLContents(url string, headers map[string]string, data []byte, etag string) (string, error) {
+	req, err := http.NewRequest("PUT", url, bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	switch etag {
+	case "":
+	case "*":
+		req.Header.Set("If-None-Match", "*")
+	default:
+		req.Header.Set("If-Match", etag)
+	}
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return resp.Header.Get("ETag"), nil
+	case http.StatusPreconditionFailed:
+		return "", ErrPreconditionFailed
+	default:
+		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
-	return bodyBytes, nil
 }
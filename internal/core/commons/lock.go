@@ -0,0 +1,44 @@
+package commons
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+const (
+	lockFileSuffix = ".lock"
+	lockRetryDelay = 50 * time.Millisecond
+)
+
+var errLockTimeout = errors.New("timed out waiting for file lock")
+
+// LockFile acquires an exclusive lock for the given path by locking a sibling lock file with flock (LockFileEx on
+// Windows), which also works on shared (network) file systems that support locking. The OS releases the lock when
+// the process exits, so a lock is never stale and is held for as long as needed. The lock file is left in place,
+// as removing it would let another process lock a new file while a waiter still holds the old one.
+func LockFile(path string, timeout time.Duration) (unlock func(), err error) {
+	lockFile, err := os.OpenFile(path+lockFileSuffix, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		locked, err := tryLockFile(lockFile)
+		if err != nil {
+			lockFile.Close()
+			return nil, err
+		}
+		if locked {
+			return func() {
+				unlockFile(lockFile)
+				lockFile.Close()
+			}, nil
+		}
+		if time.Now().After(deadline) {
+			lockFile.Close()
+			return nil, errLockTimeout
+		}
+		time.Sleep(lockRetryDelay)
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !unix && !windows
+
+package commons
+
+import (
+	"errors"
+	"os"
+)
+
+var errLockUnsupported = errors.New("file locking is not supported on this platform")
+
+func tryLockFile(file *os.File) (bool, error) {
+	return false, errLockUnsupported
+}
+
+func unlockFile(file *os.File) {}
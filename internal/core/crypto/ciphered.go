@@ -9,28 +9,51 @@ import (
 	"slv.sh/slv/internal/core/commons"
 )
 
+type CipherAlgorithm byte
+
 type ciphered struct {
 	version     *uint8
 	keyType     *KeyType
+	algorithm   CipherAlgorithm
 	encryptedAt *time.Time
 	ciphertext  []byte
 	encryptedBy []byte
 }
 
-func timeToBytes(t time.Time) []byte {
-	tsBytes := make([]byte, 4)
-	binary.BigEndian.PutUint32(tsBytes, uint32(t.Unix()))
+func timeToBytes(t time.Time, version uint8) []byte {
+	if version == 1 {
+		tsBytes := make([]byte, 4)
+		binary.BigEndian.PutUint32(tsBytes, uint32(t.Unix()))
+		return tsBytes
+	}
+	tsBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(tsBytes, uint64(t.Unix()))
 	return tsBytes
 }
 
 func bytesToTime(timeBytes []byte) time.Time {
-	return time.Unix(int64(binary.BigEndian.Uint32(timeBytes)), 0)
+	if len(timeBytes) == 4 {
+		return time.Unix(int64(binary.BigEndian.Uint32(timeBytes)), 0)
+	}
+	return time.Unix(int64(binary.BigEndian.Uint64(timeBytes)), 0)
+}
+
+// timeLength returns the number of bytes used to store the encryption time in the given version.
+func timeLength(version uint8) int {
+	if version == 1 {
+		return 4
+	}
+	return 8
 }
 
 func (ciph ciphered) toBytes() []byte {
 	keyLenBytes := make([]byte, 2)
 	binary.BigEndian.PutUint16(keyLenBytes, uint16(len(ciph.encryptedBy)))
-	cipheredBytes := append([]byte{*ciph.version, byte(*ciph.keyType)}, timeToBytes(*ciph.encryptedAt)...)
+	cipheredBytes := []byte{*ciph.version, byte(*ciph.keyType)}
+	if *ciph.version > 1 {
+		cipheredBytes = append(cipheredBytes, byte(ciph.algorithm))
+	}
+	cipheredBytes = append(cipheredBytes, timeToBytes(*ciph.encryptedAt, *ciph.version)...)
 	cipheredBytes = append(cipheredBytes, keyLenBytes...)
 	cipheredBytes = append(cipheredBytes, ciph.encryptedBy...)
 	cipheredBytes = append(cipheredBytes, ciph.ciphertext...)
@@ -38,11 +61,31 @@ func (ciph ciphered) toBytes() []byte {
 }
 
 func cipheredFromBytes(cipheredBytes []byte) (*ciphered, error) {
+	if len(cipheredBytes) < 2 {
+		return nil, errInvalidCiphertextFormat
+	}
 	var version byte = cipheredBytes[0]
+	if version == 0 {
+		return nil, errInvalidCiphertextFormat
+	}
+	if version > cryptoVersion {
+		return nil, errUnsupportedCryptoVersion
+	}
 	var keyType KeyType = KeyType(cipheredBytes[1])
 	cipheredBytes = cipheredBytes[2:]
-	encryptedAt := bytesToTime(cipheredBytes[:4])
-	cipheredBytes = cipheredBytes[4:]
+	var algorithm CipherAlgorithm
+	if version > 1 {
+		if len(cipheredBytes) < 1 {
+			return nil, errInvalidCiphertextFormat
+		}
+		algorithm = CipherAlgorithm(cipheredBytes[0])
+		cipheredBytes = cipheredBytes[1:]
+	}
+	if len(cipheredBytes) < timeLength(version)+2 {
+		return nil, errInvalidCiphertextFormat
+	}
+	encryptedAt := bytesToTime(cipheredBytes[:timeLength(version)])
+	cipheredBytes = cipheredBytes[timeLength(version):]
 	keyLen := binary.BigEndian.Uint16(cipheredBytes[:2])
 	cipheredBytes = cipheredBytes[2:]
 	if len(cipheredBytes) < int(keyLen) {
@@ -53,6 +96,7 @@ func cipheredFromBytes(cipheredBytes []byte) (*ciphered, error) {
 	return &ciphered{
 		version:     &version,
 		keyType:     &keyType,
+		algorithm:   algorithm,
 		encryptedAt: &encryptedAt,
 		encryptedBy: encryptedBy,
 		ciphertext:  ciphertext,
@@ -78,6 +122,21 @@ func (ciph *ciphered) EncryptedAt() time.Time {
 	return *ciph.encryptedAt
 }
 
+// Version returns the cryptography version the data was encrypted with.
+func (ciph *ciphered) Version() uint8 {
+	return *ciph.version
+}
+
+// Algorithm returns the cipher algorithm of the data. It is unset for data encrypted with version 1.
+func (ciph *ciphered) Algorithm() CipherAlgorithm {
+	return ciph.algorithm
+}
+
+// IsOutdated reports whether the data was encrypted with an older cryptography version and can be upgraded.
+func (ciph *ciphered) IsOutdated() bool {
+	return *ciph.version < cryptoVersion
+}
+
 type SealedSecret struct {
 	*ciphered
 	hash *[]byte
@@ -3,18 +3,38 @@ package crypto
 import (
 	"errors"
 
+	"golang.org/x/crypto/chacha20poly1305"
 	"slv.sh/slv/internal/core/config"
 )
 
 const (
-	publicKeyAbbrev          = "PK" // PK = Public Key
-	secretKeyAbbrev          = "SK" // SK = Secret Key
-	wrappedKeyAbbrev         = "WK" // WK = Wrapped Key
-	sealedSecretAbbrev       = "SS" // SS = Sealed Secret
-	slvPrefix                = config.AppNameUpperCase
-	cryptoVersion      uint8 = 1
+	publicKeyAbbrev             = "PK"  // PK = Public Key
+	secretKeyAbbrev             = "SK"  // SK = Secret Key
+	wrappedKeyAbbrev            = "WK"  // WK = Wrapped Key
+	sealedSecretAbbrev          = "SS"  // SS = Sealed Secret
+	verificationKeyAbbrev       = "VK"  // VK = Verification Key
+	signatureAbbrev             = "SIG" // SIG = Signature
+	slvPrefix                   = config.AppNameUpperCase
+	keyVersion            uint8 = 1
+	cryptoVersion         uint8 = 2
+	keyHeaderLength             = 3 // version, key kind (0 for secret, 1 for public) and key type
 
 	hashMaxLength = 4
+
+	XipherXChaCha20Poly1305 CipherAlgorithm = 1
+
+	dataKeyLength   = chacha20poly1305.KeySize
+	streamChunkSize = 64 * 1024
+
+	Ed25519 SignatureAlgorithm = 1
+	MLDSA65 SignatureAlgorithm = 2
+
+	signingKeyInfo       = "slv-signing-key"
+	signatureKeyIdLength = 8
+
+	fingerprintDigestLength   = 8
+	fingerprintChecksumLength = 2
+	fingerprintGroupLength    = 4
 )
 
 var (
@@ -27,4 +47,23 @@ var (
 	errDecryptionFailed         = errors.New("decryption failed")
 	errSecretKeyMismatch        = errors.New("given secret key cannot decrypt the data")
 	errInvalidCiphertextFormat  = errors.New("invalid ciphertext format")
+	errUnsupportedCipher        = errors.New("unsupported cipher algorithm")
+	errUnboundSecret            = errors.New("secret sealed with an older cryptography version is not accepted once upgraded")
+	errGeneratingDataKey        = errors.New("error generating a new data key")
+	errInvalidStream            = errors.New("encrypted stream is invalid or truncated")
+	errStreamClosed             = errors.New("encrypted stream is closed")
+
+	errDerivingSigningKey            = errors.New("error deriving signing key from the secret key")
+	errSigningFailed                 = errors.New("signing failed")
+	errInvalidVerificationKeyFormat  = errors.New("invalid verification key format")
+	errInvalidSignatureFormat        = errors.New("invalid signature format")
+	errUnsupportedSignatureAlgorithm = errors.New("unsupported signature algorithm")
+	errInvalidFingerprint            = errors.New("invalid public key fingerprint")
+
+	errInvalidMnemonicWord     = errors.New("invalid mnemonic: unknown word")
+	errInvalidMnemonic         = errors.New("invalid mnemonic: checksum mismatch")
+	errInvalidShamirParameters = errors.New("invalid secret sharing parameters: the threshold must be at least 2 and at most the number of shares (up to 255)")
+	errInvalidShamirShares     = errors.New("invalid secret shares")
+
+	errRemoteSecretKey = errors.New("the secret key is held remotely and cannot be exported")
 )
@@ -1,24 +1,54 @@
 package crypto
 
 import (
+	"crypto/rand"
+	"encoding/binary"
 	"time"
 
 	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
 )
 
-func (publicKey *PublicKey) encrypt(data []byte) (*ciphered, error) {
-	ciphertext, err := publicKey.pubKey.Encrypt(data, true, false)
+// seal encrypts a random data key to the public key and encrypts the data with the data key using
+// XChaCha20-Poly1305, authenticating the given associated data along with it.
+func (publicKey *PublicKey) seal(data, associatedData []byte) ([]byte, error) {
+	dataKey, err := NewDataKey()
 	if err != nil {
+		return nil, err
+	}
+	wrappedDataKey, err := publicKey.pubKey.Encrypt(dataKey, false, false)
+	if err != nil {
+		return nil, errEncryptionFailed
+	}
+	aead, err := chacha20poly1305.NewX(dataKey)
+	if err != nil {
+		return nil, errEncryptionFailed
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
 		return nil, errEncryptionFailed
 	}
+	sealed := binary.BigEndian.AppendUint16(nil, uint16(len(wrappedDataKey)))
+	sealed = append(sealed, wrappedDataKey...)
+	sealed = append(sealed, nonce...)
+	return aead.Seal(sealed, nonce, data, associatedData), nil
+}
+
+func (publicKey *PublicKey) encrypt(data, associatedData []byte) (*ciphered, error) {
+	ciphertext, err := publicKey.seal(data, associatedData)
+	if err != nil {
+		return nil, err
+	}
 	pubKeyBytes, err := publicKey.toBytes()
 	if err != nil {
 		return nil, err
 	}
+	version := cryptoVersion
 	currentTime := time.Now()
 	return &ciphered{
-		version:     publicKey.version,
+		version:     &version,
 		keyType:     publicKey.keyType,
+		algorithm:   XipherXChaCha20Poly1305,
 		encryptedAt: &currentTime,
 		encryptedBy: pubKeyBytes,
 		ciphertext:  ciphertext,
@@ -30,7 +60,7 @@ func (publicKey *PublicKey) EncryptKey(secretKey SecretKey) (wrappedKey *Wrapped
 	if err != nil {
 		return nil, err
 	}
-	ciphered, err := publicKey.encrypt(secretKeyBytes)
+	ciphered, err := publicKey.encrypt(secretKeyBytes, nil)
 	if err == nil {
 		wrappedKey = &WrappedKey{
 			ciphered: ciphered,
@@ -43,8 +73,10 @@ func hash(data []byte) []byte {
 	return argon2.IDKey(data, nil, 16, 64, 1, uint32(hashMaxLength))
 }
 
-func (publicKey *PublicKey) EncryptSecret(secret []byte, hashEnabled bool) (sealedSecret *SealedSecret, err error) {
-	ciphered, err := publicKey.encrypt(secret)
+// EncryptSecret seals the secret to the public key. The associated data is not encrypted, but the sealed secret
+// can only be decrypted by presenting the same associated data, binding the secret to the context it is stored in.
+func (publicKey *PublicKey) EncryptSecret(secret []byte, hashEnabled bool, associatedData []byte) (sealedSecret *SealedSecret, err error) {
+	ciphered, err := publicKey.encrypt(secret, associatedData)
 	if err == nil {
 		sealedSecret = &SealedSecret{
 			ciphered: ciphered,
@@ -57,7 +89,32 @@ func (publicKey *PublicKey) EncryptSecret(secret []byte, hashEnabled bool) (seal
 	return
 }
 
-func (secretKey *SecretKey) decrypt(ciphered *ciphered) (data []byte, err error) {
+func (secretKey *SecretKey) open(sealed, associatedData []byte) ([]byte, error) {
+	if len(sealed) < 2 {
+		return nil, errInvalidCiphertextFormat
+	}
+	wrappedDataKeyLen := int(binary.BigEndian.Uint16(sealed[:2]))
+	sealed = sealed[2:]
+	if len(sealed) < wrappedDataKeyLen+chacha20poly1305.NonceSizeX {
+		return nil, errInvalidCiphertextFormat
+	}
+	dataKey, err := secretKey.DecryptWrappedKey(sealed[:wrappedDataKeyLen])
+	if err != nil || len(dataKey) != dataKeyLength {
+		return nil, errDecryptionFailed
+	}
+	sealed = sealed[wrappedDataKeyLen:]
+	aead, err := chacha20poly1305.NewX(dataKey)
+	if err != nil {
+		return nil, errDecryptionFailed
+	}
+	data, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], associatedData)
+	if err != nil {
+		return nil, errDecryptionFailed
+	}
+	return data, nil
+}
+
+func (secretKey *SecretKey) decrypt(ciphered *ciphered, associatedData []byte) (data []byte, err error) {
 	var pqPubKey, eccPubKey *PublicKey
 	pqPubKey, err = secretKey.PublicKey(true)
 	if err == nil {
@@ -66,19 +123,36 @@ func (secretKey *SecretKey) decrypt(ciphered *ciphered) (data []byte, err error)
 	if err != nil || (!ciphered.IsEncryptedBy(pqPubKey) && !ciphered.IsEncryptedBy(eccPubKey)) {
 		return nil, errSecretKeyMismatch
 	}
-	data, err = secretKey.privKey.Decrypt(ciphered.ciphertext)
-	if err != nil {
-		return nil, errDecryptionFailed
+	switch {
+	case *ciphered.version == 1:
+		if data, err = secretKey.DecryptWrappedKey(ciphered.ciphertext); err != nil {
+			return nil, errDecryptionFailed
+		}
+		return
+	case ciphered.algorithm == XipherXChaCha20Poly1305:
+		return secretKey.open(ciphered.ciphertext, associatedData)
 	}
-	return
+	return nil, errUnsupportedCipher
+}
+
+// DecryptSecret decrypts the sealed secret with the associated data it was sealed with.
+// Secrets sealed with version 1 are not bound to any associated data, which is ignored for them.
+func (secretKey *SecretKey) DecryptSecret(sealedSecret SealedSecret, associatedData []byte) (secret []byte, err error) {
+	return secretKey.decrypt(sealedSecret.ciphered, associatedData)
 }
 
-func (secretKey *SecretKey) DecryptSecret(sealedSecret SealedSecret) (secret []byte, err error) {
-	return secretKey.decrypt(sealedSecret.ciphered)
+// DecryptBoundSecret decrypts the sealed secret like DecryptSecret, but rejects secrets sealed with version 1, which
+// are not bound to any associated data. It is to be used once everything sealed in the context has been upgraded,
+// so that an older sealed value cannot take the place of a bound one.
+func (secretKey *SecretKey) DecryptBoundSecret(sealedSecret SealedSecret, associatedData []byte) (secret []byte, err error) {
+	if sealedSecret.Version() < cryptoVersion {
+		return nil, errUnboundSecret
+	}
+	return secretKey.decrypt(sealedSecret.ciphered, associatedData)
 }
 
 func (secretKey *SecretKey) DecryptKey(wrappedKey WrappedKey) (*SecretKey, error) {
-	decryptedSecretKeyBytes, err := secretKey.decrypt(wrappedKey.ciphered)
+	decryptedSecretKeyBytes, err := secretKey.decrypt(wrappedKey.ciphered, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,159 @@
+package crypto
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+const testKeyType KeyType = 'T'
+
+func newTestKeyPair(t *testing.T) (*SecretKey, *PublicKey) {
+	t.Helper()
+	secretKey, err := NewSecretKey(testKeyType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey, err := secretKey.PublicKey(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return secretKey, publicKey
+}
+
+// sealV1 seals the secret the way version 1 did, encrypting it straight to the public key without any
+// associated data.
+func sealV1(t *testing.T, publicKey *PublicKey, secret []byte) *SealedSecret {
+	t.Helper()
+	ciphertext, err := publicKey.pubKey.Encrypt(secret, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKeyBytes, err := publicKey.toBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	version := uint8(1)
+	encryptedAt := time.Now()
+	sealedSecret := &SealedSecret{ciphered: &ciphered{
+		version:     &version,
+		keyType:     publicKey.keyType,
+		encryptedAt: &encryptedAt,
+		encryptedBy: publicKeyBytes,
+		ciphertext:  ciphertext,
+	}}
+	parsed := new(SealedSecret)
+	if err = parsed.FromString(sealedSecret.String()); err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func TestSealedSecretRoundTrip(t *testing.T) {
+	secretKey, publicKey := newTestKeyPair(t)
+	tests := []struct {
+		name           string
+		secret         []byte
+		hashEnabled    bool
+		associatedData []byte
+	}{
+		{"without associated data", []byte("secret"), false, nil},
+		{"with associated data", []byte("secret"), false, []byte("vault\nitem")},
+		{"with hash", []byte("secret"), true, []byte("vault\nitem")},
+		{"large secret", bytes.Repeat([]byte{0xA5}, 1<<16), false, []byte("vault\nitem")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sealedSecret, err := publicKey.EncryptSecret(tt.secret, tt.hashEnabled, tt.associatedData)
+			if err != nil {
+				t.Fatal(err)
+			}
+			parsed := new(SealedSecret)
+			if err = parsed.FromString(sealedSecret.String()); err != nil {
+				t.Fatal(err)
+			}
+			if parsed.Version() != cryptoVersion || parsed.IsOutdated() {
+				t.Fatalf("sealed with version %d, want %d", parsed.Version(), cryptoVersion)
+			}
+			if (parsed.Hash() != "") != tt.hashEnabled {
+				t.Fatalf("hash present = %v, want %v", parsed.Hash() != "", tt.hashEnabled)
+			}
+			for _, decrypt := range []func(SealedSecret, []byte) ([]byte, error){
+				secretKey.DecryptSecret, secretKey.DecryptBoundSecret,
+			} {
+				secret, err := decrypt(*parsed, tt.associatedData)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(secret, tt.secret) {
+					t.Fatal("decrypted secret does not match")
+				}
+			}
+		})
+	}
+}
+
+func TestSealedSecretWrongAssociatedData(t *testing.T) {
+	secretKey, publicKey := newTestKeyPair(t)
+	tests := []struct {
+		name    string
+		sealed  []byte
+		decrypt []byte
+	}{
+		{"other item", []byte("vault\nitem_a"), []byte("vault\nitem_b")},
+		{"missing", []byte("vault\nitem_a"), nil},
+		{"unexpected", nil, []byte("vault\nitem_a")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sealedSecret, err := publicKey.EncryptSecret([]byte("secret"), false, tt.sealed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = secretKey.DecryptSecret(*sealedSecret, tt.decrypt); !errors.Is(err, errDecryptionFailed) {
+				t.Fatalf("got %v, want %v", err, errDecryptionFailed)
+			}
+		})
+	}
+}
+
+func TestSealedSecretWrongSecretKey(t *testing.T) {
+	_, publicKey := newTestKeyPair(t)
+	otherSecretKey, _ := newTestKeyPair(t)
+	sealedSecret, err := publicKey.EncryptSecret([]byte("secret"), false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = otherSecretKey.DecryptSecret(*sealedSecret, nil); !errors.Is(err, errSecretKeyMismatch) {
+		t.Fatalf("got %v, want %v", err, errSecretKeyMismatch)
+	}
+}
+
+func TestSealedSecretV1(t *testing.T) {
+	secretKey, publicKey := newTestKeyPair(t)
+	secret := []byte("legacy secret")
+	sealedSecret := sealV1(t, publicKey, secret)
+	if !sealedSecret.IsOutdated() {
+		t.Fatal("version 1 secret is not reported as outdated")
+	}
+	tests := []struct {
+		name    string
+		decrypt func(SealedSecret, []byte) ([]byte, error)
+		wantErr error
+	}{
+		{"accepted before upgrade", secretKey.DecryptSecret, nil},
+		{"rejected after upgrade", secretKey.DecryptBoundSecret, errUnboundSecret},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decrypted, err := tt.decrypt(*sealedSecret, []byte("vault\nitem"))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !bytes.Equal(decrypted, secret) {
+				t.Fatal("decrypted secret does not match")
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base32"
+	"strings"
+)
+
+var fingerprintEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func fingerprintChecksum(digest []byte) []byte {
+	checksum := sha256.Sum256(digest)
+	return checksum[:fingerprintChecksumLength]
+}
+
+func formatFingerprint(fingerprintBytes []byte) string {
+	encoded := fingerprintEncoding.EncodeToString(fingerprintBytes)
+	groups := make([]string, 0, len(encoded)/fingerprintGroupLength)
+	for i := 0; i < len(encoded); i += fingerprintGroupLength {
+		groups = append(groups, encoded[i:min(i+fingerprintGroupLength, len(encoded))])
+	}
+	return strings.Join(groups, "-")
+}
+
+// Fingerprint returns a short and stable identifier of the public key that is easy to compare by eye.
+// It is a truncated SHA-256 hash of the public key followed by a checksum, rendered in base32 (e.g. ABCD-EFGH-IJKL-MNOP).
+// An empty string is returned if the public key cannot be serialized.
+func (publicKey *PublicKey) Fingerprint() string {
+	publicKeyBytes, err := publicKey.toBytes()
+	if err != nil {
+		return ""
+	}
+	digest := sha256.Sum256(publicKeyBytes)
+	return formatFingerprint(append(digest[:fingerprintDigestLength:fingerprintDigestLength],
+		fingerprintChecksum(digest[:fingerprintDigestLength])...))
+}
+
+// ParseFingerprint validates the given fingerprint against its checksum and returns it in the canonical form.
+// Fingerprints are accepted in any case, with or without the separators.
+func ParseFingerprint(fingerprint string) (string, error) {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(fingerprint))
+	decoded, err := fingerprintEncoding.DecodeString(normalized)
+	if err != nil || len(decoded) != fingerprintDigestLength+fingerprintChecksumLength {
+		return "", errInvalidFingerprint
+	}
+	if !bytes.Equal(decoded[fingerprintDigestLength:], fingerprintChecksum(decoded[:fingerprintDigestLength])) {
+		return "", errInvalidFingerprint
+	}
+	return formatFingerprint(decoded), nil
+}
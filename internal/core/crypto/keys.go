@@ -28,15 +28,15 @@ func (publicKey *PublicKey) Type() KeyType {
 }
 
 func publicKeyFromBytes(bytes []byte) (*PublicKey, error) {
-	if bytes[1] != 1 {
+	if len(bytes) <= keyHeaderLength || bytes[1] != 1 {
 		return nil, errInvalidPublicKeyFormat
 	}
-	if bytes[0] > cryptoVersion {
+	if bytes[0] > keyVersion {
 		return nil, errUnsupportedCryptoVersion
 	}
 	var version uint8 = bytes[0]
 	var keyType KeyType = KeyType(bytes[2])
-	pubKey, err := xipher.ParsePublicKey(bytes[3:])
+	pubKey, err := xipher.ParsePublicKey(bytes[keyHeaderLength:])
 	if err != nil {
 		return nil, errInvalidPublicKeyFormat
 	}
@@ -81,6 +81,7 @@ type SecretKey struct {
 	privKey      *xipher.SecretKey
 	pqPublicKey  *PublicKey
 	eccPublicKey *PublicKey
+	remote       RemoteKey
 	restricted   bool
 }
 
@@ -101,7 +102,7 @@ func NewSecretKeyForPassword(password []byte, keyType KeyType) (secretKey *Secre
 }
 
 func newSecretKey(privKey *xipher.SecretKey, keyType KeyType, restricted bool) *SecretKey {
-	version := cryptoVersion
+	version := keyVersion
 	return &SecretKey{
 		version:    &version,
 		keyType:    &keyType,
@@ -111,6 +112,9 @@ func newSecretKey(privKey *xipher.SecretKey, keyType KeyType, restricted bool) *
 }
 
 func (secretKey *SecretKey) getPublicKey(postQuantum bool) (*PublicKey, error) {
+	if secretKey.remote != nil {
+		return nil, errDerivingPublicKey
+	}
 	pubKey, err := secretKey.privKey.PublicKey(postQuantum)
 	if err != nil {
 		return nil, errDerivingPublicKey
@@ -142,15 +146,15 @@ func (secretKey *SecretKey) PublicKey(postQuantum bool) (publicKey *PublicKey, e
 }
 
 func SecretKeyFromBytes(bytes []byte) (*SecretKey, error) {
-	if bytes[1] != 0 {
+	if len(bytes) <= keyHeaderLength || bytes[1] != 0 {
 		return nil, errInvalidSecretKeyFormat
 	}
-	if bytes[0] > cryptoVersion {
+	if bytes[0] > keyVersion {
 		return nil, errUnsupportedCryptoVersion
 	}
 	var version uint8 = bytes[0]
 	var keyType KeyType = KeyType(bytes[2])
-	privKey, err := xipher.ParseSecretKey(bytes[3:])
+	privKey, err := xipher.ParseSecretKey(bytes[keyHeaderLength:])
 	if err != nil {
 		return nil, errInvalidSecretKeyFormat
 	}
@@ -182,6 +186,9 @@ func SecretKeyFromString(secretKeyStr string) (*SecretKey, error) {
 }
 
 func (secretKey *SecretKey) Bytes() ([]byte, error) {
+	if secretKey.remote != nil {
+		return nil, errRemoteSecretKey
+	}
 	if privKeyBytes, err := secretKey.privKey.Bytes(); err != nil {
 		return nil, err
 	} else {
@@ -0,0 +1,80 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+)
+
+const (
+	mnemonicBitsPerWord     = 11
+	mnemonicChecksumLength  = 2
+	mnemonicWordIndexMask   = 1<<mnemonicBitsPerWord - 1
+	mnemonicWordListLength  = 1 << mnemonicBitsPerWord
+	mnemonicWordsSeparator  = " "
+	mnemonicMinimumDataSize = 1
+)
+
+var mnemonicWordIndex = func() map[string]int {
+	wordIndex := make(map[string]int, mnemonicWordListLength)
+	for i, word := range wordlists.English {
+		wordIndex[word] = i
+	}
+	return wordIndex
+}()
+
+// EncodeMnemonic renders the data as words of the BIP39 English word list. Unlike BIP39, data of any length
+// is accepted: the data and a checksum are split into groups of 11 bits, each picking a word, with the last
+// group padded with zero bits.
+func EncodeMnemonic(data []byte) string {
+	checksum := sha256.Sum256(data)
+	payload := append(bytes.Clone(data), checksum[:mnemonicChecksumLength]...)
+	var words []string
+	var buffer, bufferedBits int
+	for _, b := range payload {
+		buffer = buffer<<8 | int(b)
+		bufferedBits += 8
+		for bufferedBits >= mnemonicBitsPerWord {
+			bufferedBits -= mnemonicBitsPerWord
+			words = append(words, wordlists.English[(buffer>>bufferedBits)&mnemonicWordIndexMask])
+		}
+	}
+	if bufferedBits > 0 {
+		words = append(words, wordlists.English[(buffer<<(mnemonicBitsPerWord-bufferedBits))&mnemonicWordIndexMask])
+	}
+	return strings.Join(words, mnemonicWordsSeparator)
+}
+
+// DecodeMnemonic returns the data encoded with EncodeMnemonic after verifying its checksum.
+// Words are matched regardless of case and of the whitespace between them.
+func DecodeMnemonic(mnemonic string) ([]byte, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	var payload []byte
+	var buffer, bufferedBits int
+	for _, word := range words {
+		index, ok := mnemonicWordIndex[word]
+		if !ok {
+			return nil, errInvalidMnemonicWord
+		}
+		buffer = buffer<<mnemonicBitsPerWord | index
+		bufferedBits += mnemonicBitsPerWord
+		for bufferedBits >= 8 {
+			bufferedBits -= 8
+			payload = append(payload, byte(buffer>>bufferedBits))
+		}
+		buffer &= 1<<bufferedBits - 1
+	}
+	// The padding of the last word may add a trailing byte of zero bits; the checksum tells whether it is data.
+	for _, length := range []int{len(payload), len(payload) - 1} {
+		if length < mnemonicMinimumDataSize+mnemonicChecksumLength || (length*8+mnemonicBitsPerWord-1)/mnemonicBitsPerWord != len(words) {
+			continue
+		}
+		data, checksum := payload[:length-mnemonicChecksumLength], payload[length-mnemonicChecksumLength:length]
+		if expected := sha256.Sum256(data); bytes.Equal(checksum, expected[:mnemonicChecksumLength]) {
+			return data, nil
+		}
+	}
+	return nil, errInvalidMnemonic
+}
@@ -0,0 +1,108 @@
+package crypto
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+)
+
+func TestMnemonicRoundTrip(t *testing.T) {
+	for _, size := range []int{1, 2, 16, 31, 32, 33, 64} {
+		data := make([]byte, size)
+		for i := range data {
+			data[i] = byte(i*31 + size)
+		}
+		mnemonic := EncodeMnemonic(data)
+		for _, variant := range []string{mnemonic, strings.ToUpper(mnemonic), " " + strings.ReplaceAll(mnemonic, " ", "\n\t ") + " "} {
+			decoded, err := DecodeMnemonic(variant)
+			if err != nil {
+				t.Fatalf("size %d: %v", size, err)
+			}
+			if !bytes.Equal(decoded, data) {
+				t.Fatalf("size %d: decoded data does not match", size)
+			}
+		}
+	}
+}
+
+func TestMnemonicInvalid(t *testing.T) {
+	words := strings.Fields(EncodeMnemonic(bytes.Repeat([]byte{0x3C}, 32)))
+	replaceWord := func(position int, word string) string {
+		replaced := append([]string{}, words...)
+		replaced[position] = word
+		return strings.Join(replaced, " ")
+	}
+	otherWord := func(word string) string {
+		if word == wordlists.English[0] {
+			return wordlists.English[1]
+		}
+		return wordlists.English[0]
+	}
+	tests := []struct {
+		name     string
+		mnemonic string
+		wantErr  error
+	}{
+		{"empty", "", errInvalidMnemonic},
+		{"first word changed", replaceWord(0, otherWord(words[0])), errInvalidMnemonic},
+		{"middle word changed", replaceWord(len(words)/2, otherWord(words[len(words)/2])), errInvalidMnemonic},
+		{"last word changed", replaceWord(len(words)-1, otherWord(words[len(words)-1])), errInvalidMnemonic},
+		{"words swapped", strings.Join(append([]string{words[1], words[0]}, words[2:]...), " "), errInvalidMnemonic},
+		{"word missing", strings.Join(words[1:], " "), errInvalidMnemonic},
+		{"word added", strings.Join(append([]string{words[0]}, words...), " "), errInvalidMnemonic},
+		{"unknown word", replaceWord(3, "notaword"), errInvalidMnemonicWord},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeMnemonic(tt.mnemonic); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSecretKeyFromMnemonic(t *testing.T) {
+	secretKey, _ := newTestKeyPair(t)
+	secretKeyBytes, err := secretKey.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{"secret key", secretKeyBytes, nil},
+		{"single byte", []byte{keyVersion}, errInvalidSecretKeyFormat},
+		{"two bytes", []byte{keyVersion, 0}, errInvalidSecretKeyFormat},
+		{"header only", secretKeyBytes[:keyHeaderLength], errInvalidSecretKeyFormat},
+		{"truncated key", secretKeyBytes[:len(secretKeyBytes)-1], errInvalidSecretKeyFormat},
+		{"public key kind", append([]byte{keyVersion, 1}, secretKeyBytes[2:]...), errInvalidSecretKeyFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A short mnemonic still carries a valid checksum, so only the key parsing can reject it
+			data, err := DecodeMnemonic(EncodeMnemonic(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			restored, err := SecretKeyFromBytes(data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			restoredBytes, err := restored.Bytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(restoredBytes, secretKeyBytes) {
+				t.Fatal("restored secret key does not match")
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package crypto
+
+// RemoteKey performs the operations that need a secret key on behalf of a process that does not hold it, such as
+// the SLV agent serving a secret key over a socket. A SecretKey implements RemoteKey for the key it holds.
+type RemoteKey interface {
+	DecryptWrappedKey(wrapped []byte) ([]byte, error)
+	VerificationKey(postQuantum bool) (*VerificationKey, error)
+	Sign(data []byte, postQuantum bool) (*Signature, error)
+}
+
+// NewRemoteSecretKey returns a secret key whose operations are performed by the given remote key. The secret key
+// itself is never available to the process, so it cannot be serialized.
+func NewRemoteSecretKey(publicKeyEC, publicKeyPQ *PublicKey, remote RemoteKey) (*SecretKey, error) {
+	if publicKeyEC == nil || publicKeyPQ == nil || *publicKeyEC.keyType != *publicKeyPQ.keyType {
+		return nil, errInvalidPublicKeyFormat
+	}
+	return &SecretKey{
+		version:      publicKeyEC.version,
+		keyType:      publicKeyEC.keyType,
+		eccPublicKey: publicKeyEC,
+		pqPublicKey:  publicKeyPQ,
+		remote:       remote,
+		restricted:   true,
+	}, nil
+}
+
+// IsRemote reports whether the operations of the secret key are performed by a remote key.
+func (secretKey *SecretKey) IsRemote() bool {
+	return secretKey.remote != nil
+}
+
+// DecryptWrappedKey decrypts data that was encrypted to the public key of the secret key as a whole, such as the
+// data key that a sealed secret is encrypted with.
+func (secretKey *SecretKey) DecryptWrappedKey(wrapped []byte) ([]byte, error) {
+	if secretKey.remote != nil {
+		return secretKey.remote.DecryptWrappedKey(wrapped)
+	}
+	return secretKey.privKey.Decrypt(wrapped)
+}
@@ -0,0 +1,99 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+)
+
+const (
+	shamirMaxShares = 255
+)
+
+// gf256Mul multiplies in GF(2^8) with the AES reduction polynomial, in constant time.
+func gf256Mul(a, b byte) byte {
+	var product byte
+	for range 8 {
+		product ^= byte(subtle.ConstantTimeByteEq(b&1, 1)) * a
+		a = a<<1 ^ byte(subtle.ConstantTimeByteEq(a>>7, 1))*0x1b
+		b >>= 1
+	}
+	return product
+}
+
+// gf256Inv returns the multiplicative inverse in GF(2^8) as a^254.
+func gf256Inv(a byte) byte {
+	inverse := a
+	for range 6 {
+		a = gf256Mul(a, a)
+		inverse = gf256Mul(inverse, a)
+	}
+	return gf256Mul(inverse, inverse)
+}
+
+// SplitSecret splits the secret into the given number of shares using Shamir's secret sharing over GF(2^8),
+// so that any threshold of them reconstruct the secret while fewer reveal nothing about it.
+// Each share is as long as the secret plus one trailing byte that holds its x coordinate.
+func SplitSecret(secret []byte, shares, threshold int) ([][]byte, error) {
+	if len(secret) == 0 || threshold < 2 || shares < threshold || shares > shamirMaxShares {
+		return nil, errInvalidShamirParameters
+	}
+	coefficients := make([]byte, threshold-1)
+	result := make([][]byte, shares)
+	for i := range result {
+		result[i] = make([]byte, len(secret)+1)
+		result[i][len(secret)] = byte(i + 1)
+	}
+	for position, secretByte := range secret {
+		if _, err := rand.Read(coefficients); err != nil {
+			return nil, err
+		}
+		for _, share := range result {
+			x := share[len(secret)]
+			// Horner's method on secretByte + c1*x + c2*x^2 + ...
+			var y byte
+			for i := len(coefficients) - 1; i >= 0; i-- {
+				y = gf256Mul(y^coefficients[i], x)
+			}
+			share[position] = y ^ secretByte
+		}
+	}
+	return result, nil
+}
+
+// CombineShares reconstructs the secret from shares made by SplitSecret through Lagrange interpolation at zero.
+// Combining fewer shares than the threshold yields a wrong secret rather than an error.
+func CombineShares(shares [][]byte) ([]byte, error) {
+	if len(shares) < 2 {
+		return nil, errInvalidShamirShares
+	}
+	length := len(shares[0])
+	xs := make([]byte, len(shares))
+	for i, share := range shares {
+		if len(share) != length || length < 2 {
+			return nil, errInvalidShamirShares
+		}
+		xs[i] = share[length-1]
+		if xs[i] == 0 {
+			return nil, errInvalidShamirShares
+		}
+		for _, x := range xs[:i] {
+			if x == xs[i] {
+				return nil, errInvalidShamirShares
+			}
+		}
+	}
+	secret := make([]byte, length-1)
+	for i, share := range shares {
+		// basis = prod over j != i of x_j / (x_j - x_i), where subtraction is XOR in GF(2^8)
+		basis := byte(1)
+		for j, x := range xs {
+			if j != i {
+				basis = gf256Mul(basis, gf256Mul(x, gf256Inv(x^xs[i])))
+			}
+		}
+		for position := range secret {
+			secret[position] ^= gf256Mul(share[position], basis)
+		}
+	}
+	return secret, nil
+}
@@ -0,0 +1,126 @@
+package crypto
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// combinations returns every subset of size k of the indices below n.
+func combinations(n, k int) [][]int {
+	if k == 0 {
+		return [][]int{nil}
+	}
+	var result [][]int
+	for i := k - 1; i < n; i++ {
+		for _, rest := range combinations(i, k-1) {
+			result = append(result, append(rest, i))
+		}
+	}
+	return result
+}
+
+func pickShares(shares [][]byte, indices []int) [][]byte {
+	picked := make([][]byte, 0, len(indices))
+	for _, i := range indices {
+		picked = append(picked, shares[i])
+	}
+	return picked
+}
+
+func TestShamirThreshold(t *testing.T) {
+	secret := []byte("a secret key of some length")
+	tests := []struct {
+		name      string
+		shares    int
+		threshold int
+	}{
+		{"2 of 2", 2, 2},
+		{"2 of 3", 3, 2},
+		{"3 of 5", 5, 3},
+		{"5 of 5", 5, 5},
+		{"4 of 7", 7, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shares, err := SplitSecret(secret, tt.shares, tt.threshold)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(shares) != tt.shares {
+				t.Fatalf("got %d shares, want %d", len(shares), tt.shares)
+			}
+			for k := tt.threshold; k <= tt.shares; k++ {
+				for _, indices := range combinations(tt.shares, k) {
+					combined, err := CombineShares(pickShares(shares, indices))
+					if err != nil {
+						t.Fatal(err)
+					}
+					if !bytes.Equal(combined, secret) {
+						t.Fatalf("shares %v do not recover the secret", indices)
+					}
+				}
+			}
+			if tt.threshold == 2 {
+				return
+			}
+			for _, indices := range combinations(tt.shares, tt.threshold-1) {
+				combined, err := CombineShares(pickShares(shares, indices))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if bytes.Equal(combined, secret) {
+					t.Fatalf("shares %v below the threshold recover the secret", indices)
+				}
+			}
+		})
+	}
+}
+
+func TestShamirInvalidParameters(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    []byte
+		shares    int
+		threshold int
+	}{
+		{"empty secret", nil, 3, 2},
+		{"threshold of 1", []byte("secret"), 3, 1},
+		{"threshold above shares", []byte("secret"), 2, 3},
+		{"too many shares", []byte("secret"), shamirMaxShares + 1, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := SplitSecret(tt.secret, tt.shares, tt.threshold); !errors.Is(err, errInvalidShamirParameters) {
+				t.Fatalf("got %v, want %v", err, errInvalidShamirParameters)
+			}
+		})
+	}
+}
+
+func TestShamirInvalidShares(t *testing.T) {
+	shares, err := SplitSecret([]byte("secret"), 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zeroX := bytes.Clone(shares[1])
+	zeroX[len(zeroX)-1] = 0
+	tests := []struct {
+		name   string
+		shares [][]byte
+	}{
+		{"no shares", nil},
+		{"single share", shares[:1]},
+		{"duplicate share", [][]byte{shares[0], shares[0]}},
+		{"mismatched lengths", [][]byte{shares[0], shares[1][1:]}},
+		{"zero x coordinate", [][]byte{shares[0], zeroX}},
+		{"too short", [][]byte{{1}, {2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CombineShares(tt.shares); !errors.Is(err, errInvalidShamirShares) {
+				t.Fatalf("got %v, want %v", err, errInvalidShamirShares)
+			}
+		})
+	}
+}
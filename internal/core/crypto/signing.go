@@ -0,0 +1,214 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/hkdf"
+	"crypto/sha256"
+	"strings"
+
+	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
+	"slv.sh/slv/internal/core/commons"
+)
+
+type SignatureAlgorithm byte
+
+// VerificationKey is the public half of a signing key that is derived from a secret key.
+type VerificationKey struct {
+	version   *uint8
+	keyType   *KeyType
+	algorithm SignatureAlgorithm
+	key       []byte
+}
+
+// Signature is a detached signature that identifies the verification key it can be verified with.
+type Signature struct {
+	version   *uint8
+	algorithm SignatureAlgorithm
+	keyId     []byte
+	sig       []byte
+}
+
+func (secretKey *SecretKey) signingSeed() ([]byte, error) {
+	secretKeyBytes, err := secretKey.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return hkdf.Key(sha256.New, secretKeyBytes, nil, signingKeyInfo, mldsa65.SeedSize)
+}
+
+// VerificationKey returns the verification key of the signing key derived from the secret key.
+// Post quantum keys use ML-DSA-65 while the others use Ed25519.
+func (secretKey *SecretKey) VerificationKey(postQuantum bool) (*VerificationKey, error) {
+	if secretKey.remote != nil {
+		return secretKey.remote.VerificationKey(postQuantum)
+	}
+	seed, err := secretKey.signingSeed()
+	if err != nil {
+		return nil, errDerivingSigningKey
+	}
+	verificationKey := &VerificationKey{
+		version: secretKey.version,
+		keyType: secretKey.keyType,
+	}
+	if postQuantum {
+		publicKey, _ := mldsa65.NewKeyFromSeed((*[mldsa65.SeedSize]byte)(seed))
+		verificationKey.algorithm = MLDSA65
+		verificationKey.key = publicKey.Bytes()
+	} else {
+		verificationKey.algorithm = Ed25519
+		verificationKey.key = ed25519.NewKeyFromSeed(seed[:ed25519.SeedSize]).Public().(ed25519.PublicKey)
+	}
+	return verificationKey, nil
+}
+
+// Sign signs the given data with the signing key derived from the secret key.
+func (secretKey *SecretKey) Sign(data []byte, postQuantum bool) (*Signature, error) {
+	if secretKey.remote != nil {
+		return secretKey.remote.Sign(data, postQuantum)
+	}
+	seed, err := secretKey.signingSeed()
+	if err != nil {
+		return nil, errDerivingSigningKey
+	}
+	verificationKey, err := secretKey.VerificationKey(postQuantum)
+	if err != nil {
+		return nil, err
+	}
+	signature := &Signature{
+		version:   secretKey.version,
+		algorithm: verificationKey.algorithm,
+		keyId:     verificationKey.id(),
+	}
+	if postQuantum {
+		_, privateKey := mldsa65.NewKeyFromSeed((*[mldsa65.SeedSize]byte)(seed))
+		signature.sig = make([]byte, mldsa65.SignatureSize)
+		if err = mldsa65.SignTo(privateKey, data, nil, false, signature.sig); err != nil {
+			return nil, errSigningFailed
+		}
+	} else {
+		signature.sig = ed25519.Sign(ed25519.NewKeyFromSeed(seed[:ed25519.SeedSize]), data)
+	}
+	return signature, nil
+}
+
+func (verificationKey *VerificationKey) toBytes() []byte {
+	return append([]byte{*verificationKey.version, 2, byte(*verificationKey.keyType), byte(verificationKey.algorithm)},
+		verificationKey.key...)
+}
+
+func (verificationKey *VerificationKey) id() []byte {
+	digest := sha256.Sum256(verificationKey.toBytes())
+	return digest[:signatureKeyIdLength]
+}
+
+// KeyId returns the short identifier of the key that signatures made with it carry.
+func (verificationKey *VerificationKey) KeyId() string {
+	return commons.Encode(verificationKey.id())
+}
+
+func (verificationKey *VerificationKey) Algorithm() SignatureAlgorithm {
+	return verificationKey.algorithm
+}
+
+func (verificationKey *VerificationKey) Equals(other *VerificationKey) bool {
+	return other != nil && bytes.Equal(verificationKey.toBytes(), other.toBytes())
+}
+
+func (verificationKey VerificationKey) String() string {
+	return slvPrefix + "_" + string(*verificationKey.keyType) + verificationKeyAbbrev + "_" +
+		commons.Encode(verificationKey.toBytes())
+}
+
+func VerificationKeyFromString(verificationKeyStr string) (*VerificationKey, error) {
+	sliced := strings.Split(verificationKeyStr, "_")
+	if len(sliced) != 3 || sliced[0] != slvPrefix {
+		return nil, errInvalidVerificationKeyFormat
+	}
+	decoded, err := commons.Decode(sliced[2])
+	if err != nil || len(decoded) < 4 || decoded[1] != 2 {
+		return nil, errInvalidVerificationKeyFormat
+	}
+	if decoded[0] > keyVersion {
+		return nil, errUnsupportedCryptoVersion
+	}
+	var version uint8 = decoded[0]
+	var keyType KeyType = KeyType(decoded[2])
+	verificationKey := &VerificationKey{
+		version:   &version,
+		keyType:   &keyType,
+		algorithm: SignatureAlgorithm(decoded[3]),
+		key:       decoded[4:],
+	}
+	switch verificationKey.algorithm {
+	case Ed25519:
+		if len(verificationKey.key) != ed25519.PublicKeySize {
+			return nil, errInvalidVerificationKeyFormat
+		}
+	case MLDSA65:
+		if len(verificationKey.key) != mldsa65.PublicKeySize {
+			return nil, errInvalidVerificationKeyFormat
+		}
+	default:
+		return nil, errUnsupportedSignatureAlgorithm
+	}
+	if len(sliced[1]) != 3 || !strings.HasPrefix(sliced[1], string(keyType)) ||
+		!strings.HasSuffix(sliced[1], verificationKeyAbbrev) {
+		return nil, errInvalidVerificationKeyFormat
+	}
+	return verificationKey, nil
+}
+
+// Verify reports whether the signature over the given data was made with the signing key of the verification key.
+func (verificationKey *VerificationKey) Verify(data []byte, signature *Signature) bool {
+	if signature == nil || signature.algorithm != verificationKey.algorithm || !bytes.Equal(signature.keyId, verificationKey.id()) {
+		return false
+	}
+	switch verificationKey.algorithm {
+	case Ed25519:
+		return ed25519.Verify(ed25519.PublicKey(verificationKey.key), data, signature.sig)
+	case MLDSA65:
+		publicKey := &mldsa65.PublicKey{}
+		if err := publicKey.UnmarshalBinary(verificationKey.key); err != nil {
+			return false
+		}
+		return mldsa65.Verify(publicKey, data, nil, signature.sig)
+	}
+	return false
+}
+
+// IsSignedBy reports whether the signature claims to be made with the signing key of the given verification key.
+// Use VerificationKey.Verify to check the signature itself.
+func (signature *Signature) IsSignedBy(verificationKey *VerificationKey) bool {
+	return verificationKey != nil && bytes.Equal(signature.keyId, verificationKey.id())
+}
+
+func (signature *Signature) KeyId() string {
+	return commons.Encode(signature.keyId)
+}
+
+func (signature Signature) String() string {
+	signatureBytes := append([]byte{*signature.version, byte(signature.algorithm)}, signature.keyId...)
+	return slvPrefix + "_" + signatureAbbrev + "_" + commons.Encode(append(signatureBytes, signature.sig...))
+}
+
+func SignatureFromString(signatureStr string) (*Signature, error) {
+	sliced := strings.Split(signatureStr, "_")
+	if len(sliced) != 3 || sliced[0] != slvPrefix || sliced[1] != signatureAbbrev {
+		return nil, errInvalidSignatureFormat
+	}
+	decoded, err := commons.Decode(sliced[2])
+	if err != nil || len(decoded) <= 2+signatureKeyIdLength {
+		return nil, errInvalidSignatureFormat
+	}
+	if decoded[0] > keyVersion {
+		return nil, errUnsupportedCryptoVersion
+	}
+	var version uint8 = decoded[0]
+	return &Signature{
+		version:   &version,
+		algorithm: SignatureAlgorithm(decoded[1]),
+		keyId:     decoded[2 : 2+signatureKeyIdLength],
+		sig:       decoded[2+signatureKeyIdLength:],
+	}, nil
+}
@@ -0,0 +1,164 @@
+package crypto
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// The stream is split into chunks of streamChunkSize bytes that are sealed independently with ChaCha20-Poly1305.
+// The nonce of each chunk is its big-endian index followed by a flag that marks the last chunk, so chunks cannot be
+// reordered, dropped or truncated without failing authentication.
+
+type streamNonce [chacha20poly1305.NonceSize]byte
+
+func (nonce *streamNonce) set(counter uint64, last bool) {
+	binary.BigEndian.PutUint64(nonce[len(nonce)-9:len(nonce)-1], counter)
+	if last {
+		nonce[len(nonce)-1] = 1
+	} else {
+		nonce[len(nonce)-1] = 0
+	}
+}
+
+type streamWriter struct {
+	dst            io.Writer
+	aead           cipher.AEAD
+	associatedData []byte
+	nonce          streamNonce
+	counter        uint64
+	buf            []byte
+	closed         bool
+}
+
+type streamReader struct {
+	src            *bufio.Reader
+	aead           cipher.AEAD
+	associatedData []byte
+	nonce          streamNonce
+	counter        uint64
+	chunk          []byte
+	buf            []byte
+	done           bool
+}
+
+// NewDataKey returns a random key to be used with NewStreamWriter and NewStreamReader.
+func NewDataKey() ([]byte, error) {
+	dataKey := make([]byte, dataKeyLength)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, errGeneratingDataKey
+	}
+	return dataKey, nil
+}
+
+// NewStreamWriter returns a writer that encrypts everything written to it into dst with the given data key,
+// authenticating the associated data along with every chunk. Close must be called to write the last chunk.
+func NewStreamWriter(dst io.Writer, dataKey, associatedData []byte) (io.WriteCloser, error) {
+	aead, err := chacha20poly1305.New(dataKey)
+	if err != nil {
+		return nil, errEncryptionFailed
+	}
+	return &streamWriter{
+		dst:            dst,
+		aead:           aead,
+		associatedData: associatedData,
+		buf:            make([]byte, 0, streamChunkSize),
+	}, nil
+}
+
+func (w *streamWriter) Write(p []byte) (n int, err error) {
+	if w.closed {
+		return 0, errStreamClosed
+	}
+	for len(p) > 0 {
+		// A full chunk is only sealed once more data arrives, so that the last chunk is never left empty
+		if len(w.buf) == streamChunkSize {
+			if err = w.flush(false); err != nil {
+				return n, err
+			}
+		}
+		copied := copy(w.buf[len(w.buf):streamChunkSize], p)
+		w.buf = w.buf[:len(w.buf)+copied]
+		p = p[copied:]
+		n += copied
+	}
+	return n, nil
+}
+
+func (w *streamWriter) flush(last bool) error {
+	w.nonce.set(w.counter, last)
+	if _, err := w.dst.Write(w.aead.Seal(nil, w.nonce[:], w.buf, w.associatedData)); err != nil {
+		return err
+	}
+	w.counter++
+	w.buf = w.buf[:0]
+	return nil
+}
+
+// Close writes the last chunk. It does not close the underlying writer.
+func (w *streamWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.flush(true)
+}
+
+// NewStreamReader returns a reader that decrypts a stream written by NewStreamWriter with the same data key and
+// associated data. Reading fails if the stream has been modified or truncated.
+func NewStreamReader(src io.Reader, dataKey, associatedData []byte) (io.Reader, error) {
+	aead, err := chacha20poly1305.New(dataKey)
+	if err != nil {
+		return nil, errDecryptionFailed
+	}
+	encryptedChunkSize := streamChunkSize + aead.Overhead()
+	return &streamReader{
+		src:            bufio.NewReaderSize(src, encryptedChunkSize),
+		aead:           aead,
+		associatedData: associatedData,
+		chunk:          make([]byte, encryptedChunkSize),
+	}, nil
+}
+
+func (r *streamReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.readChunk(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *streamReader) readChunk() error {
+	n, err := io.ReadFull(r.src, r.chunk)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return err
+	}
+	if n < r.aead.Overhead() {
+		return errInvalidStream
+	}
+	last := n < len(r.chunk)
+	if !last {
+		if _, err = r.src.Peek(1); err == io.EOF {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	}
+	r.nonce.set(r.counter, last)
+	if r.buf, err = r.aead.Open(r.chunk[:0], r.nonce[:], r.chunk[:n], r.associatedData); err != nil {
+		return errInvalidStream
+	}
+	r.counter++
+	r.done = last
+	return nil
+}
@@ -0,0 +1,138 @@
+package crypto
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const encryptedChunkSize = streamChunkSize + chacha20poly1305.Overhead
+
+func newTestDataKey(t *testing.T) []byte {
+	t.Helper()
+	dataKey, err := NewDataKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dataKey
+}
+
+func encryptStream(t *testing.T, dataKey, associatedData, plaintext []byte) []byte {
+	t.Helper()
+	var encrypted bytes.Buffer
+	writer, err := NewStreamWriter(&encrypted, dataKey, associatedData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Written in uneven pieces so that chunk boundaries do not line up with the writes
+	for len(plaintext) > 0 {
+		piece := min(len(plaintext), 1000)
+		if _, err = writer.Write(plaintext[:piece]); err != nil {
+			t.Fatal(err)
+		}
+		plaintext = plaintext[piece:]
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return encrypted.Bytes()
+}
+
+func decryptStream(dataKey, associatedData, encrypted []byte) ([]byte, error) {
+	reader, err := NewStreamReader(bytes.NewReader(encrypted), dataKey, associatedData)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(reader)
+}
+
+func TestStreamRoundTrip(t *testing.T) {
+	dataKey := newTestDataKey(t)
+	associatedData := []byte("vault\nfile")
+	tests := []struct {
+		name       string
+		size       int
+		wantChunks int
+	}{
+		{"empty", 0, 1},
+		{"single byte", 1, 1},
+		{"one chunk less a byte", streamChunkSize - 1, 1},
+		{"exactly one chunk", streamChunkSize, 1},
+		{"one chunk and a byte", streamChunkSize + 1, 2},
+		{"several chunks", 3*streamChunkSize + 100, 4},
+		{"exactly several chunks", 3 * streamChunkSize, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plaintext := make([]byte, tt.size)
+			for i := range plaintext {
+				plaintext[i] = byte(i * 7)
+			}
+			encrypted := encryptStream(t, dataKey, associatedData, plaintext)
+			wantSize := tt.size + tt.wantChunks*chacha20poly1305.Overhead
+			if len(encrypted) != wantSize {
+				t.Fatalf("encrypted size %d, want %d", len(encrypted), wantSize)
+			}
+			decrypted, err := decryptStream(dataKey, associatedData, encrypted)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(decrypted, plaintext) {
+				t.Fatal("decrypted stream does not match")
+			}
+		})
+	}
+}
+
+func TestStreamTampered(t *testing.T) {
+	dataKey := newTestDataKey(t)
+	associatedData := []byte("vault\nfile")
+	plaintext := bytes.Repeat([]byte{0x5A}, 2*streamChunkSize+100)
+	encrypted := encryptStream(t, dataKey, associatedData, plaintext)
+	chunk := func(i int) []byte {
+		return encrypted[i*encryptedChunkSize : min((i+1)*encryptedChunkSize, len(encrypted))]
+	}
+	tests := []struct {
+		name           string
+		encrypted      []byte
+		dataKey        []byte
+		associatedData []byte
+	}{
+		{"empty", nil, dataKey, associatedData},
+		{"truncated", encrypted[:len(encrypted)-1], dataKey, associatedData},
+		{"truncated at chunk boundary", encrypted[:2*encryptedChunkSize], dataKey, associatedData},
+		{"first chunk dropped", encrypted[encryptedChunkSize:], dataKey, associatedData},
+		{"chunks reordered", bytes.Join([][]byte{chunk(1), chunk(0), chunk(2)}, nil), dataKey, associatedData},
+		{"chunk appended", bytes.Join([][]byte{encrypted, chunk(0)}, nil), dataKey, associatedData},
+		{"byte flipped", func() []byte {
+			tampered := bytes.Clone(encrypted)
+			tampered[encryptedChunkSize+10] ^= 1
+			return tampered
+		}(), dataKey, associatedData},
+		{"wrong associated data", encrypted, dataKey, []byte("vault\nother")},
+		{"wrong data key", encrypted, newTestDataKey(t), associatedData},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decryptStream(tt.dataKey, tt.associatedData, tt.encrypted); !errors.Is(err, errInvalidStream) {
+				t.Fatalf("got %v, want %v", err, errInvalidStream)
+			}
+		})
+	}
+}
+
+func TestStreamWriteAfterClose(t *testing.T) {
+	writer, err := NewStreamWriter(io.Discard, newTestDataKey(t), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = writer.Write([]byte("data")); !errors.Is(err, errStreamClosed) {
+		t.Fatalf("got %v, want %v", err, errStreamClosed)
+	}
+}
@@ -0,0 +1,175 @@
+package environments
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"slv.sh/slv/internal/core/commons"
+	"slv.sh/slv/internal/core/crypto"
+)
+
+// recoveryShare is a Shamir share of an environment secret key, sealed to the public key of the environment
+// that holds it. The public key of the environment that was backed up identifies the share.
+type recoveryShare struct {
+	PublicKey string `json:"pk"`
+	Threshold int    `json:"t"`
+	Share     string `json:"s"`
+}
+
+func (rs *recoveryShare) string() (string, error) {
+	data, err := commons.Serialize(*rs)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s_%s_%s", slvPrefix, recoveryShareAbbrev, data), nil
+}
+
+func recoveryShareFromString(recoveryShareStr string) (*recoveryShare, error) {
+	sliced := strings.Split(strings.TrimSpace(recoveryShareStr), "_")
+	if len(sliced) != 3 || sliced[0] != slvPrefix || sliced[1] != recoveryShareAbbrev {
+		return nil, errInvalidRecoveryShare
+	}
+	rs := new(recoveryShare)
+	if err := commons.Deserialize(sliced[2], rs); err != nil {
+		return nil, errInvalidRecoveryShare
+	}
+	return rs, nil
+}
+
+// IsSealedRecoveryShare reports whether the string is a recovery share sealed to an environment, as opposed to
+// an opened share in words.
+func IsSealedRecoveryShare(recoveryShareStr string) bool {
+	return strings.HasPrefix(strings.TrimSpace(recoveryShareStr), slvPrefix+"_"+recoveryShareAbbrev+"_")
+}
+
+// NewMnemonicBackup returns the secret key as words of the BIP39 English word list, to be written down on paper.
+func NewMnemonicBackup(secretKey *crypto.SecretKey) (string, error) {
+	secretKeyBytes, err := secretKey.Bytes()
+	if err != nil {
+		return "", err
+	}
+	return crypto.EncodeMnemonic(secretKeyBytes), nil
+}
+
+// SecretKeyFromMnemonic restores a secret key backed up with NewMnemonicBackup.
+func SecretKeyFromMnemonic(mnemonic string) (*crypto.SecretKey, error) {
+	secretKeyBytes, err := crypto.DecodeMnemonic(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.SecretKeyFromBytes(secretKeyBytes)
+}
+
+func recoverySecretChecksum(secretKeyBytes []byte) []byte {
+	checksum := sha256.Sum256(secretKeyBytes)
+	return checksum[:recoverySecretChecksumLength]
+}
+
+// NewRecoveryShares splits the secret key into one share for each of the given holders, any threshold of which
+// reconstruct the secret key. Each share is sealed to the public key of its holder.
+func NewRecoveryShares(secretKey *crypto.SecretKey, threshold int, holders []*crypto.PublicKey) ([]string, error) {
+	secretKeyBytes, err := secretKey.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := secretKey.PublicKey(false)
+	if err != nil {
+		return nil, err
+	}
+	publicKeyStr, err := publicKey.String()
+	if err != nil {
+		return nil, err
+	}
+	shares, err := crypto.SplitSecret(append(secretKeyBytes, recoverySecretChecksum(secretKeyBytes)...), len(holders), threshold)
+	if err != nil {
+		return nil, err
+	}
+	recoveryShares := make([]string, len(holders))
+	for i, holder := range holders {
+		sealedShare, err := holder.EncryptSecret(shares[i], false, []byte(recoveryShareAssociatedData))
+		if err != nil {
+			return nil, err
+		}
+		rs := &recoveryShare{
+			PublicKey: publicKeyStr,
+			Threshold: threshold,
+			Share:     sealedShare.String(),
+		}
+		if recoveryShares[i], err = rs.string(); err != nil {
+			return nil, err
+		}
+	}
+	return recoveryShares, nil
+}
+
+func openRecoveryShare(rs *recoveryShare, secretKey *crypto.SecretKey) ([]byte, error) {
+	if secretKey == nil {
+		return nil, errRecoveryShareNotAccessible
+	}
+	sealedShare := new(crypto.SealedSecret)
+	if err := sealedShare.FromString(rs.Share); err != nil {
+		return nil, errInvalidRecoveryShare
+	}
+	share, err := secretKey.DecryptSecret(*sealedShare, []byte(recoveryShareAssociatedData))
+	if err != nil {
+		return nil, errRecoveryShareNotAccessible
+	}
+	return share, nil
+}
+
+// OpenRecoveryShare decrypts a recovery share with the secret key of its holder and returns it as words, so that
+// the holder can hand it over to whoever recovers the environment.
+func OpenRecoveryShare(recoveryShareStr string, secretKey *crypto.SecretKey) (string, error) {
+	rs, err := recoveryShareFromString(recoveryShareStr)
+	if err != nil {
+		return "", err
+	}
+	share, err := openRecoveryShare(rs, secretKey)
+	if err != nil {
+		return "", err
+	}
+	return crypto.EncodeMnemonic(share), nil
+}
+
+// SecretKeyFromRecoveryShares reconstructs a secret key from recovery shares, each of which is either sealed to
+// the given secret key or opened into words with OpenRecoveryShare.
+func SecretKeyFromRecoveryShares(recoveryShares []string, secretKey *crypto.SecretKey) (*crypto.SecretKey, error) {
+	var shares [][]byte
+	threshold := 0
+	for _, recoveryShareStr := range recoveryShares {
+		var share []byte
+		if IsSealedRecoveryShare(recoveryShareStr) {
+			rs, err := recoveryShareFromString(recoveryShareStr)
+			if err != nil {
+				return nil, err
+			}
+			if share, err = openRecoveryShare(rs, secretKey); err != nil {
+				return nil, err
+			}
+			threshold = max(threshold, rs.Threshold)
+		} else {
+			var err error
+			if share, err = crypto.DecodeMnemonic(recoveryShareStr); err != nil {
+				return nil, err
+			}
+		}
+		shares = append(shares, share)
+	}
+	if len(shares) < max(threshold, 2) {
+		return nil, errNotEnoughRecoveryShares
+	}
+	secret, err := crypto.CombineShares(shares)
+	if err != nil {
+		return nil, err
+	}
+	if len(secret) <= recoverySecretChecksumLength {
+		return nil, errRecoverySharesMismatch
+	}
+	secretKeyBytes, checksum := secret[:len(secret)-recoverySecretChecksumLength], secret[len(secret)-recoverySecretChecksumLength:]
+	if !bytes.Equal(checksum, recoverySecretChecksum(secretKeyBytes)) {
+		return nil, errRecoverySharesMismatch
+	}
+	return crypto.SecretKeyFromBytes(secretKeyBytes)
+}
@@ -14,17 +14,34 @@ const (
 	SERVICE            EnvType        = "service"
 	slvPrefix                         = config.AppNameUpperCase
 	selfEnvFileName                   = ".self"
+
+	recoveryShareAbbrev          = "ERS" // Environment Recovery Share
+	recoveryShareAssociatedData  = "slv-env-recovery-share"
+	recoverySecretChecksumLength = 4
+
+	envSigningContext   = "slv-env-manifest-entry"
+	adminSigningContext = "slv-env-manifest-admin"
 )
 
 var (
 	errInvalidEnvDef                 = errors.New("invalid environment definition string")
 	errInvalidEnvironmentType        = errors.New("invalid environment type")
 	errEnvironmentPublicKeyNotFound  = errors.New("environment public key not found")
+	errEnvironmentSigningKeyNotFound = errors.New("environment signing key not found")
 	errManifestPathExistsAlready     = errors.New("manifest path exists already")
 	errManifestNotFound              = errors.New("manifest not found")
 	errWritingManifest               = errors.New("error in writing manifest")
 	errRootExistsAlready             = errors.New("root environment exists already")
-	errMarkingSelfEnvBindingNotFound = errors.New("error in marking environment as self - env secret binding not found")
 	errMarkingSelfNonUserEnv         = errors.New("error in marking environment as self - non user environment")
 	errEnvNotFound                   = errors.New("environment not found")
+	errInvalidRecoveryShare          = errors.New("invalid recovery share")
+	errRecoveryShareNotAccessible    = errors.New("recovery share is not accessible using the current session")
+	errNotEnoughRecoveryShares       = errors.New("not enough recovery shares to reconstruct the secret key")
+	errRecoverySharesMismatch        = errors.New("the recovery shares do not reconstruct a secret key: more shares may be needed, or they belong to different backups")
+	errEnvNotSigned                  = errors.New("the environment is not signed by the root or an admin of the profile")
+	errEnvSignatureInvalid           = errors.New("the environment does not match its signature")
+	errEnvSignerNotTrusted           = errors.New("the environment is signed by neither the root nor an admin of the profile")
+	errNotRootOrAdmin                = errors.New("only the root or an admin of the profile can sign environments")
+	errNotRoot                       = errors.New("only the root of the profile can delegate admins")
+	errRootNotSet                    = errors.New("the profile has no root environment with a signing key")
 )
@@ -19,7 +19,9 @@ type Environment struct {
 	EnvType       EnvType  `json:"type,omitempty" yaml:"type,omitempty"`
 	Tags          []string `json:"tags,omitempty" yaml:"tags,omitempty"`
 	SecretBinding string   `json:"binding,omitempty" yaml:"binding,omitempty"`
+	SigningKey    string   `json:"signingKey,omitempty" yaml:"signingKey,omitempty"`
 	publicKey     *crypto.PublicKey
+	signingKey    *crypto.VerificationKey
 }
 
 func (eType *EnvType) isValid() bool {
@@ -49,7 +51,13 @@ func New(name string, envType EnvType, pq bool) (*Environment, *crypto.SecretKey
 			return nil, nil, err
 		}
 		env, err := newEnvironmentForPublicKey(name, envType, publicKey)
-		return env, secretKey, err
+		if err != nil {
+			return nil, nil, err
+		}
+		if err = env.SetSigningKey(secretKey, pq); err != nil {
+			return nil, nil, err
+		}
+		return env, secretKey, nil
 	}
 	return nil, nil, err
 }
@@ -67,6 +75,39 @@ func (env *Environment) GetPublicKey() (publicKey *crypto.PublicKey, err error)
 	return env.publicKey, nil
 }
 
+// Fingerprint returns the fingerprint of the environment public key, or an empty string if the public key is invalid.
+func (env *Environment) Fingerprint() string {
+	if publicKey, err := env.GetPublicKey(); err == nil && publicKey != nil {
+		return publicKey.Fingerprint()
+	}
+	return ""
+}
+
+// GetSigningKey returns the key used to verify the changes signed by the environment.
+func (env *Environment) GetSigningKey() (signingKey *crypto.VerificationKey, err error) {
+	if env.signingKey == nil {
+		if env.SigningKey == "" {
+			return nil, errEnvironmentSigningKeyNotFound
+		}
+		if signingKey, err = crypto.VerificationKeyFromString(env.SigningKey); err != nil {
+			return nil, err
+		}
+		env.signingKey = signingKey
+	}
+	return env.signingKey, nil
+}
+
+// SetSigningKey sets the signing key of the environment derived from its secret key.
+func (env *Environment) SetSigningKey(secretKey *crypto.SecretKey, pq bool) error {
+	signingKey, err := secretKey.VerificationKey(pq)
+	if err != nil {
+		return err
+	}
+	env.signingKey = signingKey
+	env.SigningKey = signingKey.String()
+	return nil
+}
+
 func (env *Environment) SetEmail(email string) {
 	env.Email = email
 }
@@ -112,10 +153,9 @@ func GetSelf() *Environment {
 	return env
 }
 
+// SetAsSelf registers the environment as the self environment of the host. The secret binding may be empty
+// for environments whose secret key is held by a provider that needs no binding, such as the OS keyring.
 func (env *Environment) SetAsSelf() error {
-	if env.SecretBinding == "" {
-		return errMarkingSelfEnvBindingNotFound
-	}
 	if env.EnvType != USER {
 		return errMarkingSelfNonUserEnv
 	}
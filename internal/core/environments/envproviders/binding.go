@@ -0,0 +1,255 @@
+package envproviders
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+const (
+	envar_SLV_ENV_SECRET_BINDING_ORDER = "SLV_ENV_SECRET_BINDING_ORDER"
+)
+
+var (
+	errSecretBindingProviderNotFound = errors.New("no binding with the given provider found in the environment secret binding")
+	errRemovingLastSecretBinding     = errors.New("cannot remove the only binding of the environment secret key")
+	errSecretBindingExists           = errors.New("the environment secret binding already holds a binding with the given provider")
+)
+
+// bindings returns the individual provider bindings held by the secret binding.
+func (esb *envSecretBinding) bindings() []*envSecretBinding {
+	if len(esb.Bindings) > 0 {
+		return esb.Bindings
+	}
+	return []*envSecretBinding{esb}
+}
+
+// newEnvSecretBinding keeps the single provider format when there is only one binding, so that the result
+// can still be read by releases that do not know about multiple bindings.
+func newEnvSecretBinding(bindings []*envSecretBinding) *envSecretBinding {
+	if len(bindings) == 1 {
+		return bindings[0]
+	}
+	return &envSecretBinding{Bindings: bindings}
+}
+
+// orderedBindings moves the bindings of the providers listed in SLV_ENV_SECRET_BINDING_ORDER to the front,
+// in the listed order. The remaining bindings follow in the order they were added.
+func orderedBindings(bindings []*envSecretBinding) []*envSecretBinding {
+	var order []string
+	for providerId := range strings.SplitSeq(os.Getenv(envar_SLV_ENV_SECRET_BINDING_ORDER), ",") {
+		if providerId = strings.TrimSpace(providerId); providerId != "" {
+			order = append(order, providerId)
+		}
+	}
+	rank := func(esb *envSecretBinding) int {
+		if index := slices.Index(order, esb.Provider); index >= 0 {
+			return index
+		}
+		return len(order)
+	}
+	ordered := slices.Clone(bindings)
+	slices.SortStableFunc(ordered, func(a, b *envSecretBinding) int {
+		return rank(a) - rank(b)
+	})
+	return ordered
+}
+
+// unbindSecretKey returns the secret key bytes along with the secret binding they were unbound from. An empty
+// secret binding is unbound by the providers that need no binding reference.
+func unbindSecretKey(envSecretBindingStr string) (secretKeyBytes []byte, esb *envSecretBinding, err error) {
+	registerDefaultProviders()
+	if envSecretBindingStr == "" {
+		var providersWithoutRef []provider
+		for _, provider := range providerMap {
+			if !provider.refRequired {
+				providersWithoutRef = append(providersWithoutRef, *provider)
+			}
+		}
+		for _, provider := range providersWithoutRef {
+			if secretKeyBytes, err = (provider.unbind)(nil); err == nil {
+				return secretKeyBytes, &envSecretBinding{Provider: provider.id}, nil
+			}
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, errEnvSecretBindingUnspecified
+	}
+	if esb, err = envSecretBindingFromString(envSecretBindingStr); err != nil {
+		return nil, nil, err
+	}
+	bindings := orderedBindings(esb.bindings())
+	var errs []error
+	for _, binding := range bindings {
+		var provider *provider
+		if provider, err = getProvider(binding.Provider); err == nil {
+			if secretKeyBytes, err = (provider.unbind)(binding.Ref); err == nil {
+				return secretKeyBytes, esb, nil
+			}
+		}
+		if len(bindings) == 1 {
+			return nil, nil, err
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", binding.Provider, err))
+	}
+	return nil, nil, errors.Join(errs...)
+}
+
+// RebindSecretKey unbinds the secret key with the given secret binding and binds it with the given provider alone,
+// replacing all existing bindings. The secret key, and hence the public key of the environment, stays the same.
+// The new secret binding is empty for providers that need no binding reference.
+func RebindSecretKey(envSecretBindingStr, providerId string, inputs map[string]string) (string, error) {
+	if err := validateProviderInputs(providerId, inputs); err != nil {
+		return "", err
+	}
+	skBytes, _, err := unbindSecretKey(envSecretBindingStr)
+	if err != nil {
+		return "", err
+	}
+	return BindSecretKey(skBytes, providerId, inputs)
+}
+
+// BindSecretKey binds the given secret key bytes with the given provider alone, such as a secret key recovered
+// from a backup. The secret binding is empty for providers that need no binding reference.
+func BindSecretKey(secretKeyBytes []byte, providerId string, inputs map[string]string) (string, error) {
+	if err := validateProviderInputs(providerId, inputs); err != nil {
+		return "", err
+	}
+	provider, err := getProvider(providerId)
+	if err != nil {
+		return "", err
+	}
+	ref, err := (provider.bind)(secretKeyBytes, inputs)
+	if err != nil {
+		return "", err
+	}
+	if !provider.refRequired && len(ref) == 0 {
+		return "", nil
+	}
+	return newEnvSecretBinding([]*envSecretBinding{{Provider: providerId, Ref: ref}}).string()
+}
+
+func validateProviderInputs(providerId string, inputs map[string]string) error {
+	provider, err := getProvider(providerId)
+	if err != nil {
+		return err
+	}
+	for _, arg := range provider.args {
+		if arg.required && inputs[arg.id] == "" {
+			return fmt.Errorf("missing required input: %s", arg.id)
+		}
+	}
+	return nil
+}
+
+// ForgetSecretBinding removes what the providers cached locally for the bindings of the previous secret binding
+// that are no longer part of the current one, such as saved passwords. It is to be called once the current
+// secret binding has been saved.
+func ForgetSecretBinding(previousEnvSecretBindingStr, currentEnvSecretBindingStr string) error {
+	toBindings := func(envSecretBindingStr string) ([]*envSecretBinding, error) {
+		if envSecretBindingStr == "" {
+			return []*envSecretBinding{{Provider: KeyringProviderId}}, nil
+		}
+		esb, err := envSecretBindingFromString(envSecretBindingStr)
+		if err != nil {
+			return nil, err
+		}
+		return esb.bindings(), nil
+	}
+	previousBindings, err := toBindings(previousEnvSecretBindingStr)
+	if err != nil {
+		return err
+	}
+	currentBindings, err := toBindings(currentEnvSecretBindingStr)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, binding := range previousBindings {
+		if slices.ContainsFunc(currentBindings, func(current *envSecretBinding) bool {
+			return current.Provider == binding.Provider &&
+				bytes.Equal(current.Ref[sealedSecretKeyRefName], binding.Ref[sealedSecretKeyRefName]) &&
+				bytes.Equal(current.Ref[keyringRefName], binding.Ref[keyringRefName])
+		}) {
+			continue
+		}
+		switch binding.Provider {
+		case PasswordProviderId:
+			errs = append(errs, forgetPassword(binding.Ref))
+		case KeyringProviderId:
+			errs = append(errs, forgetKeyring(binding.Ref))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// AddSecretBinding binds the secret key of the given secret binding with one more provider and returns the
+// new secret binding. The secret key, and hence the public key of the environment, stays the same.
+func AddSecretBinding(envSecretBindingStr, providerId string, inputs map[string]string) (string, error) {
+	if err := validateProviderInputs(providerId, inputs); err != nil {
+		return "", err
+	}
+	provider, err := getProvider(providerId)
+	if err != nil {
+		return "", err
+	}
+	skBytes, esb, err := unbindSecretKey(envSecretBindingStr)
+	if err != nil {
+		return "", err
+	}
+	bindings := esb.bindings()
+	if !provider.refRequired && slices.ContainsFunc(bindings, func(binding *envSecretBinding) bool {
+		return binding.Provider == providerId
+	}) {
+		return "", errSecretBindingExists
+	}
+	ref, err := (provider.bind)(skBytes, inputs)
+	if err != nil {
+		return "", err
+	}
+	bindings = append(slices.Clone(bindings), &envSecretBinding{Provider: providerId, Ref: ref})
+	return newEnvSecretBinding(bindings).string()
+}
+
+// RemoveSecretBinding removes the bindings of the given provider from the secret binding and returns the new
+// secret binding. At least one binding must remain.
+func RemoveSecretBinding(envSecretBindingStr, providerId string) (string, error) {
+	if envSecretBindingStr == "" {
+		return "", errRemovingLastSecretBinding
+	}
+	esb, err := envSecretBindingFromString(envSecretBindingStr)
+	if err != nil {
+		return "", err
+	}
+	bindings := esb.bindings()
+	remaining := slices.DeleteFunc(slices.Clone(bindings), func(binding *envSecretBinding) bool {
+		return binding.Provider == providerId
+	})
+	if len(remaining) == len(bindings) {
+		return "", errSecretBindingProviderNotFound
+	}
+	if len(remaining) == 0 {
+		return "", errRemovingLastSecretBinding
+	}
+	return newEnvSecretBinding(remaining).string()
+}
+
+// ListSecretBindingProviders returns the providers of the bindings held by the secret binding, in the order they are tried.
+func ListSecretBindingProviders(envSecretBindingStr string) ([]string, error) {
+	if envSecretBindingStr == "" {
+		return nil, nil
+	}
+	esb, err := envSecretBindingFromString(envSecretBindingStr)
+	if err != nil {
+		return nil, err
+	}
+	var providerIds []string
+	for _, binding := range orderedBindings(esb.bindings()) {
+		providerIds = append(providerIds, binding.Provider)
+	}
+	return providerIds, nil
+}
@@ -0,0 +1,78 @@
+package envproviders
+
+import (
+	"bytes"
+	"errors"
+
+	"slv.sh/slv/internal/core/commons"
+	"slv.sh/slv/internal/core/crypto"
+	"slv.sh/slv/internal/core/keystore"
+)
+
+const (
+	KeyringProviderId        = "keyring"
+	keyringProviderName      = "OS Keyring"
+	keyringProviderDesc      = "Secret key stored in the OS keyring (Keychain, Credential Manager or Secret Service) of the current user"
+	keyringSecretKeyIdPrefix = "slv-env-secret-key-"
+	keyringRefName           = "id"
+	// keyringSelfSecretKeyId holds the secret key bound by earlier releases, which is referred to by an empty
+	// secret binding.
+	keyringSelfSecretKeyId = "slv-self-env-secret-key"
+)
+
+var (
+	errKeyringSecretKeyNotFound = errors.New("environment secret key not found in the OS keyring")
+	errKeyringSecretKeyExists   = errors.New("the OS keyring already holds a different secret key for the environment")
+)
+
+// getKeyringSecretKeyId returns the keyring entry of the secret binding reference, falling back to the entry of
+// earlier releases for an empty secret binding.
+func getKeyringSecretKeyId(ref map[string][]byte) []byte {
+	if id := ref[keyringRefName]; len(id) > 0 {
+		return id
+	}
+	return []byte(keyringSelfSecretKeyId)
+}
+
+// bindWithKeyring stores the secret key in a keyring entry of its own, named after the fingerprint of its public
+// key, so that binding another environment never replaces it.
+func bindWithKeyring(skBytes []byte, inputs map[string]string) (ref map[string][]byte, err error) {
+	secretKey, err := crypto.SecretKeyFromBytes(skBytes)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := secretKey.PublicKey(false)
+	if err != nil {
+		return nil, err
+	}
+	id := []byte(keyringSecretKeyIdPrefix + publicKey.Fingerprint())
+	encodedSecretKey := []byte(commons.Encode(skBytes))
+	existing, err := keystore.Get(id, false)
+	switch {
+	case err == nil && !bytes.Equal(existing, encodedSecretKey):
+		return nil, errKeyringSecretKeyExists
+	case err == nil:
+		return map[string][]byte{keyringRefName: id}, nil
+	case err != keystore.ErrNotFound:
+		return nil, err
+	}
+	if err = keystore.Put(id, encodedSecretKey, false); err != nil {
+		return nil, err
+	}
+	return map[string][]byte{keyringRefName: id}, nil
+}
+
+func unBindWithKeyring(ref map[string][]byte) (secretKeyBytes []byte, err error) {
+	encodedSecretKey, err := keystore.Get(getKeyringSecretKeyId(ref), false)
+	if err != nil {
+		if err == keystore.ErrNotFound {
+			return nil, errKeyringSecretKeyNotFound
+		}
+		return nil, err
+	}
+	return commons.Decode(string(encodedSecretKey))
+}
+
+func forgetKeyring(ref map[string][]byte) error {
+	return keystore.Delete(getKeyringSecretKeyId(ref), false)
+}
@@ -90,3 +90,11 @@ func unBindWithPassword(ref map[string][]byte) (secretKeyBytes []byte, err error
 	}
 	return
 }
+
+// forgetPassword removes the password saved locally for the sealed secret key.
+func forgetPassword(ref map[string][]byte) error {
+	if sealedSecretKeyBytes := ref[sealedSecretKeyRefName]; len(sealedSecretKeyBytes) > 0 {
+		return keystore.Delete(sealedSecretKeyBytes, false)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package envproviders
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"slv.sh/slv/internal/core/commons"
+)
+
+const (
+	pgpProviderId     = "pgp"
+	pgpProviderName   = "OpenPGP"
+	pgpProviderDesc   = "OpenPGP key (decrypted with gpg, including keys on smartcards)"
+	pgpPubKeyRefName  = "pgp-pubkey"
+	pgpFingerprintRef = "fingerprint"
+	pgpArmorHeader    = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+	pgpDefaultGPG     = "gpg"
+	envar_SLV_GPG     = "SLV_GPG"
+)
+
+var (
+	errGPGNotFound         = errors.New("gpg not found: please install GnuPG or set " + envar_SLV_GPG + " to its path")
+	errInvalidPGPPublicKey = errors.New("invalid OpenPGP public key")
+	errAmbiguousPGPKey     = errors.New("more than one OpenPGP key matches: please specify the key by its fingerprint")
+	errPGPKeyCannotEncrypt = errors.New("the OpenPGP key has no valid encryption subkey")
+
+	pgpArgs = []arg{
+		{
+			id:          pgpPubKeyRefName,
+			name:        "OpenPGP Public Key",
+			required:    true,
+			description: "OpenPGP public key (file path or armored content), or a key ID, fingerprint or email to export from the gpg keyring",
+		},
+	}
+)
+
+func gpgProgram() (string, error) {
+	program := os.Getenv(envar_SLV_GPG)
+	if program == "" {
+		program = pgpDefaultGPG
+	}
+	path, err := exec.LookPath(program)
+	if err != nil {
+		return "", errGPGNotFound
+	}
+	return path, nil
+}
+
+func runGPG(stdin []byte, args ...string) ([]byte, error) {
+	program, err := gpgProgram()
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(program, args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err = cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("gpg failed: %s", msg)
+		}
+		return nil, fmt.Errorf("gpg failed: %w", err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// getPGPEntity reads the recipient key from a file, from armored content or by exporting it from the gpg keyring.
+func getPGPEntity(keyStr string) (*openpgp.Entity, error) {
+	var keyBytes []byte
+	var err error
+	if commons.FileExists(keyStr) {
+		if keyBytes, err = os.ReadFile(keyStr); err != nil {
+			return nil, err
+		}
+	} else if strings.Contains(keyStr, pgpArmorHeader) {
+		keyBytes = []byte(keyStr)
+	} else if keyBytes, err = runGPG(nil, "--batch", "--export", keyStr); err != nil {
+		return nil, err
+	}
+	var entities openpgp.EntityList
+	if bytes.Contains(keyBytes, []byte(pgpArmorHeader)) {
+		entities, err = openpgp.ReadArmoredKeyRing(bytes.NewReader(keyBytes))
+	} else {
+		entities, err = openpgp.ReadKeyRing(bytes.NewReader(keyBytes))
+	}
+	if err != nil || len(entities) == 0 {
+		return nil, errInvalidPGPPublicKey
+	}
+	if len(entities) > 1 {
+		return nil, errAmbiguousPGPKey
+	}
+	if _, ok := entities[0].EncryptionKey(time.Now()); !ok {
+		return nil, errPGPKeyCannotEncrypt
+	}
+	return entities[0], nil
+}
+
+func bindWithPGP(skBytes []byte, inputs map[string]string) (ref map[string][]byte, err error) {
+	entity, err := getPGPEntity(strings.TrimSpace(inputs[pgpPubKeyRefName]))
+	if err != nil {
+		return nil, err
+	}
+	var sealedSecretKey bytes.Buffer
+	writer, err := openpgp.Encrypt(&sealedSecretKey, []*openpgp.Entity{entity}, nil, &openpgp.FileHints{IsBinary: true},
+		&packet.Config{DefaultCipher: packet.CipherAES256})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = writer.Write(skBytes); err != nil {
+		return nil, err
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	ref = make(map[string][]byte)
+	ref[pgpFingerprintRef] = []byte(strings.ToUpper(hex.EncodeToString(entity.PrimaryKey.Fingerprint)))
+	ref[sealedSecretKeyRefName] = sealedSecretKey.Bytes()
+	return
+}
+
+func unBindWithPGP(ref map[string][]byte) (secretKeyBytes []byte, err error) {
+	sealedSecretKeyBytes := ref[sealedSecretKeyRefName]
+	if len(sealedSecretKeyBytes) == 0 {
+		return nil, errSealedSecretKeyRef
+	}
+	// gpg picks the decryption key from the message; the pinentry of gpg-agent asks for the passphrase or the smartcard PIN.
+	if secretKeyBytes, err = runGPG(sealedSecretKeyBytes, "--quiet", "--decrypt"); err != nil {
+		return nil, fmt.Errorf("failed to decrypt with OpenPGP key %s: %w", ref[pgpFingerprintRef], err)
+	}
+	return
+}
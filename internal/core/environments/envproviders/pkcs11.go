@@ -0,0 +1,268 @@
+package envproviders
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"slv.sh/slv/internal/core/input"
+)
+
+const (
+	pkcs11ProviderId   = "pkcs11"
+	pkcs11ProviderName = "PKCS#11"
+	pkcs11ProviderDesc = "PKCS#11 token or HSM (RSA OAEP SHA-256 or AES keys, using OpenSC pkcs11-tool)"
+	pkcs11ModuleRef    = "module"
+	pkcs11TokenRef     = "token"
+	pkcs11SlotRef      = "slot"
+	pkcs11KeyLabelRef  = "key-label"
+	pkcs11KeyTypeRef   = "key-type"
+	pkcs11PinSourceRef = "pin-source"
+	pkcs11IVRef        = "iv"
+
+	pkcs11KeyTypeRSA     = "rsa"
+	pkcs11KeyTypeAES     = "aes"
+	pkcs11PinSourceEnv   = "env:"
+	pkcs11PinSourceFile  = "file:"
+	pkcs11PinPrompt      = "prompt"
+	pkcs11AESBlockSize   = 16
+	pkcs11DefaultTool    = "pkcs11-tool"
+	pkcs11ToolPinEnvName = "SLV_PKCS11_TOOL_PIN"
+
+	envar_SLV_PKCS11_TOOL = "SLV_PKCS11_TOOL"
+)
+
+var (
+	errPKCS11ToolNotFound  = errors.New("pkcs11-tool not found: please install OpenSC or set " + envar_SLV_PKCS11_TOOL + " to its path")
+	errInvalidPKCS11Ref    = errors.New("invalid PKCS#11 binding: module, token and key label are required")
+	errInvalidPKCS11Key    = errors.New("invalid PKCS#11 key type: must be rsa or aes")
+	errInvalidPKCS11Pin    = errors.New("invalid PKCS#11 PIN source: must be prompt, env:<VARIABLE> or file:<PATH>")
+	errPKCS11PinNotSet     = errors.New("PKCS#11 PIN not set: please set the PIN through the PIN source or use the interactive terminal to enter the PIN")
+	errPKCS11PublicKeyRead = errors.New("failed to read an RSA public key from the PKCS#11 token")
+
+	pkcs11Args = []arg{
+		{
+			id:          pkcs11ModuleRef,
+			name:        "Module",
+			required:    true,
+			description: "Path to the PKCS#11 module library (e.g., /usr/lib/softhsm/libsofthsm2.so)",
+		},
+		{
+			id:          pkcs11TokenRef,
+			name:        "Token Label",
+			required:    false,
+			description: "Label of the token that holds the key (required unless a slot is given)",
+		},
+		{
+			id:          pkcs11SlotRef,
+			name:        "Slot",
+			required:    false,
+			description: "Slot ID of the token that holds the key (used instead of the token label)",
+		},
+		{
+			id:          pkcs11KeyLabelRef,
+			name:        "Key Label",
+			required:    true,
+			description: "Label of the key on the token to wrap the environment secret key with",
+		},
+		{
+			id:          pkcs11KeyTypeRef,
+			name:        "Key Type",
+			required:    false,
+			description: "Type of the key on the token: rsa (RSA OAEP SHA-256, default) or aes (AES CBC with padding)",
+		},
+		{
+			id:          pkcs11PinSourceRef,
+			name:        "PIN Source",
+			required:    false,
+			description: "Where to read the user PIN from when unbinding: prompt (default), env:<VARIABLE> or file:<PATH>",
+		},
+		rsaArg,
+	}
+)
+
+func pkcs11Tool() (string, error) {
+	tool := os.Getenv(envar_SLV_PKCS11_TOOL)
+	if tool == "" {
+		tool = pkcs11DefaultTool
+	}
+	path, err := exec.LookPath(tool)
+	if err != nil {
+		return "", errPKCS11ToolNotFound
+	}
+	return path, nil
+}
+
+func getPKCS11Pin(pinSource string) ([]byte, error) {
+	switch {
+	case pinSource == "" || pinSource == pkcs11PinPrompt:
+		if input.IsInteractive() {
+			return input.GetHiddenInput("Enter PKCS#11 PIN: ")
+		}
+	case strings.HasPrefix(pinSource, pkcs11PinSourceEnv):
+		if pin := os.Getenv(strings.TrimPrefix(pinSource, pkcs11PinSourceEnv)); pin != "" {
+			return []byte(pin), nil
+		}
+	case strings.HasPrefix(pinSource, pkcs11PinSourceFile):
+		pin, err := os.ReadFile(strings.TrimPrefix(pinSource, pkcs11PinSourceFile))
+		if err != nil {
+			return nil, err
+		}
+		if pin = bytes.TrimSpace(pin); len(pin) > 0 {
+			return pin, nil
+		}
+	default:
+		return nil, errInvalidPKCS11Pin
+	}
+	return nil, errPKCS11PinNotSet
+}
+
+// runPKCS11Tool runs pkcs11-tool against the token recorded in the ref. The data is piped through stdin and the
+// output read from stdout, so that neither touches the disk, and the PIN is passed through the environment, so that
+// it does not show up in the process list.
+func runPKCS11Tool(ref map[string][]byte, login bool, data []byte, args ...string) (output []byte, err error) {
+	tool, err := pkcs11Tool()
+	if err != nil {
+		return nil, err
+	}
+	cmdArgs := []string{"--module", string(ref[pkcs11ModuleRef])}
+	if slot := string(ref[pkcs11SlotRef]); slot != "" {
+		cmdArgs = append(cmdArgs, "--slot", slot)
+	} else {
+		cmdArgs = append(cmdArgs, "--token-label", string(ref[pkcs11TokenRef]))
+	}
+	cmdEnv := os.Environ()
+	if login {
+		pin, err := getPKCS11Pin(string(ref[pkcs11PinSourceRef]))
+		if err != nil {
+			return nil, err
+		}
+		cmdArgs = append(cmdArgs, "--login", "--pin", pkcs11PinSourceEnv+pkcs11ToolPinEnvName)
+		cmdEnv = append(cmdEnv, pkcs11ToolPinEnvName+"="+string(pin))
+	}
+	cmd := exec.Command(tool, append(cmdArgs, args...)...)
+	cmd.Env = cmdEnv
+	if data != nil {
+		cmd.Stdin = bytes.NewReader(data)
+	}
+	// pkcs11-tool writes its own messages to stderr, leaving stdout to the output alone.
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err = cmd.Run(); err != nil {
+		clear(stdout.Bytes())
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("pkcs11-tool failed: %s", msg)
+		}
+		return nil, fmt.Errorf("pkcs11-tool failed: %w", err)
+	}
+	return stdout.Bytes(), nil
+}
+
+func pkcs11Mechanism(ref map[string][]byte) ([]string, error) {
+	switch string(ref[pkcs11KeyTypeRef]) {
+	case pkcs11KeyTypeRSA:
+		return []string{"--mechanism", "RSA-PKCS-OAEP", "--hash-algorithm", "SHA256", "--mgf", "MGF1-SHA256"}, nil
+	case pkcs11KeyTypeAES:
+		if len(ref[pkcs11IVRef]) != pkcs11AESBlockSize {
+			return nil, errSealedSecretKeyRef
+		}
+		return []string{"--mechanism", "AES-CBC-PAD", "--iv", hex.EncodeToString(ref[pkcs11IVRef])}, nil
+	}
+	return nil, errInvalidPKCS11Key
+}
+
+func readPKCS11RSAPublicKey(ref map[string][]byte) (*rsa.PublicKey, error) {
+	der, err := runPKCS11Tool(ref, false, nil, "--read-object", "--type", "pubkey", "--label", string(ref[pkcs11KeyLabelRef]))
+	if err != nil {
+		return nil, err
+	}
+	if pub, err := x509.ParsePKIXPublicKey(der); err == nil {
+		if rsaPub, ok := pub.(*rsa.PublicKey); ok {
+			return rsaPub, nil
+		}
+	} else if rsaPub, err := x509.ParsePKCS1PublicKey(der); err == nil {
+		return rsaPub, nil
+	}
+	return nil, errPKCS11PublicKeyRead
+}
+
+func isValidPKCS11Ref(ref map[string][]byte) bool {
+	return len(ref[pkcs11ModuleRef]) > 0 && len(ref[pkcs11KeyLabelRef]) > 0 &&
+		(len(ref[pkcs11TokenRef]) > 0 || len(ref[pkcs11SlotRef]) > 0)
+}
+
+func bindWithPKCS11(skBytes []byte, inputs map[string]string) (ref map[string][]byte, err error) {
+	ref = make(map[string][]byte)
+	for _, refName := range []string{pkcs11ModuleRef, pkcs11TokenRef, pkcs11SlotRef, pkcs11KeyLabelRef, pkcs11PinSourceRef} {
+		if value := inputs[refName]; value != "" {
+			ref[refName] = []byte(value)
+		}
+	}
+	if !isValidPKCS11Ref(ref) {
+		return nil, errInvalidPKCS11Ref
+	}
+	keyType := strings.ToLower(inputs[pkcs11KeyTypeRef])
+	if keyType == "" {
+		keyType = pkcs11KeyTypeRSA
+	}
+	ref[pkcs11KeyTypeRef] = []byte(keyType)
+	if pinSource := string(ref[pkcs11PinSourceRef]); pinSource != "" && pinSource != pkcs11PinPrompt &&
+		!strings.HasPrefix(pinSource, pkcs11PinSourceEnv) && !strings.HasPrefix(pinSource, pkcs11PinSourceFile) {
+		return nil, errInvalidPKCS11Pin
+	}
+	var sealedSecretKeyBytes []byte
+	switch keyType {
+	case pkcs11KeyTypeRSA:
+		if rsaPublicKey := inputs[rsaPubKeyRefName]; rsaPublicKey != "" {
+			sealedSecretKeyBytes, err = rsaEncrypt(skBytes, []byte(rsaPublicKey))
+		} else {
+			var rsaPub *rsa.PublicKey
+			if rsaPub, err = readPKCS11RSAPublicKey(ref); err == nil {
+				sealedSecretKeyBytes, err = rsa.EncryptOAEP(sha256.New(), rand.Reader, rsaPub, skBytes, []byte(""))
+			}
+		}
+	case pkcs11KeyTypeAES:
+		iv := make([]byte, pkcs11AESBlockSize)
+		if _, err = rand.Read(iv); err != nil {
+			return nil, err
+		}
+		ref[pkcs11IVRef] = iv
+		var mechanism []string
+		if mechanism, err = pkcs11Mechanism(ref); err == nil {
+			sealedSecretKeyBytes, err = runPKCS11Tool(ref, true, skBytes,
+				append([]string{"--encrypt", "--label", string(ref[pkcs11KeyLabelRef])}, mechanism...)...)
+		}
+	default:
+		return nil, errInvalidPKCS11Key
+	}
+	if err != nil {
+		return nil, err
+	}
+	ref[sealedSecretKeyRefName] = sealedSecretKeyBytes
+	return
+}
+
+func unBindWithPKCS11(ref map[string][]byte) (secretKeyBytes []byte, err error) {
+	if !isValidPKCS11Ref(ref) {
+		return nil, errInvalidPKCS11Ref
+	}
+	sealedSecretKeyBytes := ref[sealedSecretKeyRefName]
+	if len(sealedSecretKeyBytes) == 0 {
+		return nil, errSealedSecretKeyRef
+	}
+	mechanism, err := pkcs11Mechanism(ref)
+	if err != nil {
+		return nil, err
+	}
+	return runPKCS11Tool(ref, true, sealedSecretKeyBytes,
+		append([]string{"--decrypt", "--label", string(ref[pkcs11KeyLabelRef])}, mechanism...)...)
+}
@@ -0,0 +1,133 @@
+package envproviders
+
+import (
+	"bytes"
+	"crypto/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	softHSMTokenLabel = "slv-test"
+	softHSMPin        = "1234"
+	softHSMPinEnvName = "SLV_TEST_PKCS11_PIN"
+)
+
+var softHSMModulePaths = []string{
+	"/usr/lib/softhsm/libsofthsm2.so",
+	"/usr/lib/x86_64-linux-gnu/softhsm/libsofthsm2.so",
+	"/usr/lib/aarch64-linux-gnu/softhsm/libsofthsm2.so",
+	"/usr/lib64/pkcs11/libsofthsm2.so",
+	"/usr/local/lib/softhsm/libsofthsm2.so",
+	"/opt/homebrew/lib/softhsm/libsofthsm2.so",
+}
+
+// newSoftHSMToken initializes a SoftHSM2 token in a temporary directory with an RSA key pair and an AES key, and
+// returns the path of the module. The test is skipped unless SoftHSM2 and OpenSC are installed.
+func newSoftHSMToken(t *testing.T) string {
+	t.Helper()
+	module := os.Getenv("SOFTHSM2_MODULE")
+	for _, path := range softHSMModulePaths {
+		if module != "" {
+			break
+		}
+		if _, err := os.Stat(path); err == nil {
+			module = path
+		}
+	}
+	if module == "" {
+		t.Skip("SoftHSM2 module not found: install SoftHSM2 or set SOFTHSM2_MODULE")
+	}
+	softHSMUtil, err := exec.LookPath("softhsm2-util")
+	if err != nil {
+		t.Skip("softhsm2-util not found")
+	}
+	tool, err := pkcs11Tool()
+	if err != nil {
+		t.Skip(err)
+	}
+	dir := t.TempDir()
+	tokenDir := filepath.Join(dir, "tokens")
+	if err = os.Mkdir(tokenDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	conf := filepath.Join(dir, "softhsm2.conf")
+	if err = os.WriteFile(conf, []byte("directories.tokendir = "+tokenDir+"\nobjectstore.backend = file\nlog.level = ERROR\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SOFTHSM2_CONF", conf)
+	run := func(name string, args ...string) {
+		t.Helper()
+		if output, err := exec.Command(name, args...).CombinedOutput(); err != nil {
+			t.Fatalf("%s failed: %v\n%s", filepath.Base(name), err, output)
+		}
+	}
+	run(softHSMUtil, "--init-token", "--free", "--label", softHSMTokenLabel, "--pin", softHSMPin, "--so-pin", "5678")
+	toolArgs := []string{"--module", module, "--token-label", softHSMTokenLabel, "--login", "--pin", softHSMPin}
+	run(tool, append(toolArgs, "--keypairgen", "--key-type", "rsa:2048", "--label", "slv-rsa", "--id", "01")...)
+	run(tool, append(toolArgs, "--keygen", "--key-type", "AES:32", "--label", "slv-aes", "--id", "02")...)
+	return module
+}
+
+func TestPKCS11BindUnbind(t *testing.T) {
+	module := newSoftHSMToken(t)
+	t.Setenv(softHSMPinEnvName, softHSMPin)
+	// Nothing of the secret key may be written to temporary files on the way to and from the token.
+	tmpDir := t.TempDir()
+	t.Setenv("TMPDIR", tmpDir)
+	secretKeyBytes := make([]byte, 67)
+	if _, err := rand.Read(secretKeyBytes); err != nil {
+		t.Fatal(err)
+	}
+	inputs := func(keyLabel, keyType string) map[string]string {
+		return map[string]string{
+			pkcs11ModuleRef:    module,
+			pkcs11TokenRef:     softHSMTokenLabel,
+			pkcs11KeyLabelRef:  keyLabel,
+			pkcs11KeyTypeRef:   keyType,
+			pkcs11PinSourceRef: pkcs11PinSourceEnv + softHSMPinEnvName,
+		}
+	}
+	tests := []struct {
+		name     string
+		keyLabel string
+		keyType  string
+	}{
+		{"rsa", "slv-rsa", pkcs11KeyTypeRSA},
+		{"rsa by default", "slv-rsa", ""},
+		{"aes", "slv-aes", pkcs11KeyTypeAES},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := bindWithPKCS11(secretKeyBytes, inputs(tt.keyLabel, tt.keyType))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bytes.Contains(ref[sealedSecretKeyRefName], secretKeyBytes) {
+				t.Fatal("the secret key is not sealed in the binding")
+			}
+			unboundSecretKeyBytes, err := unBindWithPKCS11(ref)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(unboundSecretKeyBytes, secretKeyBytes) {
+				t.Fatal("unbound secret key does not match")
+			}
+			if entries, err := os.ReadDir(tmpDir); err != nil || len(entries) > 0 {
+				t.Fatalf("temporary files left behind: %v %v", entries, err)
+			}
+		})
+	}
+	t.Run("wrong pin", func(t *testing.T) {
+		ref, err := bindWithPKCS11(secretKeyBytes, inputs("slv-aes", pkcs11KeyTypeAES))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv(softHSMPinEnvName, "0000")
+		if _, err = unBindWithPKCS11(ref); err == nil {
+			t.Fatal("unbound with a wrong PIN")
+		}
+	})
+}
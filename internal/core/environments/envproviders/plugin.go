@@ -0,0 +1,206 @@
+package envproviders
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"slv.sh/slv/internal/core/commons"
+	"slv.sh/slv/internal/core/config"
+)
+
+const (
+	pluginExecutablePrefix  = config.AppNameLowerCase + "-provider-"
+	pluginsConfigFileName   = "providers.yaml"
+	pluginProtocolVersion   = 1
+	pluginDescribeTimeout   = 10 * time.Second
+	pluginOperationDescribe = "describe"
+	pluginOperationBind     = "bind"
+	pluginOperationUnbind   = "unbind"
+)
+
+var (
+	pluginIdPattern      = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+	pluginProviders      = make(map[string]*provider)
+	pluginProvidersMutex sync.Mutex
+
+	errPluginEmptyResponse = errors.New("empty response from provider plugin")
+)
+
+// pluginsConfig is read from providers.yaml in the app data directory and maps provider IDs to plugin executables.
+// DiscoverOnPath opts in to running slv-provider-<id> executables found on the PATH for other IDs.
+type pluginsConfig struct {
+	Plugins        map[string]string `yaml:"plugins"`
+	DiscoverOnPath bool              `yaml:"discoverOnPath"`
+}
+
+type pluginArg struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Required    bool   `json:"required"`
+	Description string `json:"description"`
+}
+
+// pluginRequest is written as JSON to the stdin of the plugin. Byte values are base64 encoded.
+type pluginRequest struct {
+	Version   int               `json:"version"`
+	Operation string            `json:"operation"`
+	SecretKey []byte            `json:"secretKey,omitempty"`
+	Inputs    map[string]string `json:"inputs,omitempty"`
+	Ref       map[string][]byte `json:"ref,omitempty"`
+}
+
+// pluginResponse is read as JSON from the stdout of the plugin. A non empty Error fails the operation.
+type pluginResponse struct {
+	Error       string            `json:"error,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Args        []pluginArg       `json:"args,omitempty"`
+	Ref         map[string][]byte `json:"ref,omitempty"`
+	SecretKey   []byte            `json:"secretKey,omitempty"`
+}
+
+func runPlugin(ctx context.Context, path string, request *pluginRequest) (*pluginResponse, error) {
+	request.Version = pluginProtocolVersion
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Stdin = bytes.NewReader(requestBytes)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	// Plugins may prompt for PINs or passphrases and log on stderr.
+	cmd.Stderr = os.Stderr
+	runErr := cmd.Run()
+	response := new(pluginResponse)
+	if stdout.Len() == 0 {
+		if runErr != nil {
+			return nil, fmt.Errorf("provider plugin %s failed: %w", path, runErr)
+		}
+		return nil, errPluginEmptyResponse
+	}
+	if err = json.Unmarshal(stdout.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("invalid response from provider plugin %s: %w", path, err)
+	}
+	if response.Error != "" {
+		return nil, errors.New(response.Error)
+	}
+	if runErr != nil {
+		return nil, fmt.Errorf("provider plugin %s failed: %w", path, runErr)
+	}
+	return response, nil
+}
+
+func newPluginBind(path string) bind {
+	return func(skBytes []byte, inputs map[string]string) (ref map[string][]byte, err error) {
+		response, err := runPlugin(context.Background(), path, &pluginRequest{
+			Operation: pluginOperationBind,
+			SecretKey: skBytes,
+			Inputs:    inputs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(response.Ref) == 0 {
+			return nil, errPluginEmptyResponse
+		}
+		return response.Ref, nil
+	}
+}
+
+func newPluginUnbind(path string) unbind {
+	return func(ref map[string][]byte) (secretKeyBytes []byte, err error) {
+		response, err := runPlugin(context.Background(), path, &pluginRequest{
+			Operation: pluginOperationUnbind,
+			Ref:       ref,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(response.SecretKey) == 0 {
+			return nil, errPluginEmptyResponse
+		}
+		return response.SecretKey, nil
+	}
+}
+
+// findPlugin returns the plugin executable for the provider ID. Only plugins declared in providers.yaml are used,
+// unless discoverOnPath is set there, in which case a slv-provider-<id> executable on the PATH is used as well.
+func findPlugin(providerId string) (string, bool) {
+	configPath := filepath.Join(config.GetAppDataDir(), pluginsConfigFileName)
+	if !commons.FileExists(configPath) {
+		return "", false
+	}
+	pluginsConf := new(pluginsConfig)
+	if err := commons.ReadFromYAML(configPath, pluginsConf); err != nil {
+		return "", false
+	}
+	if path, found := pluginsConf.Plugins[providerId]; found {
+		return path, true
+	}
+	if pluginsConf.DiscoverOnPath {
+		if path, err := exec.LookPath(pluginExecutablePrefix + providerId); err == nil {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// getPluginProvider describes the plugin for the provider ID the first time it is asked for and keeps it as a
+// provider. It is only called for IDs that are not taken by a built-in provider.
+func getPluginProvider(providerId string) (*provider, error) {
+	pluginProvidersMutex.Lock()
+	defer pluginProvidersMutex.Unlock()
+	if provider, found := pluginProviders[providerId]; found {
+		return provider, nil
+	}
+	if !pluginIdPattern.MatchString(providerId) {
+		return nil, fmt.Errorf("unknown environment provider: %s", providerId)
+	}
+	path, found := findPlugin(providerId)
+	if !found {
+		return nil, fmt.Errorf("unknown environment provider: %s", providerId)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), pluginDescribeTimeout)
+	response, err := runPlugin(ctx, path, &pluginRequest{Operation: pluginOperationDescribe})
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	name := response.Name
+	if name == "" {
+		name = providerId
+	}
+	args := make([]arg, 0, len(response.Args))
+	for _, pArg := range response.Args {
+		if pArg.Id == "" {
+			continue
+		}
+		args = append(args, arg{
+			id:          pArg.Id,
+			name:        pArg.Name,
+			required:    pArg.Required,
+			description: pArg.Description,
+		})
+	}
+	provider := &provider{
+		id:          providerId,
+		name:        name,
+		desc:        response.Description,
+		bind:        newPluginBind(path),
+		unbind:      newPluginUnbind(path),
+		refRequired: true,
+		args:        args,
+	}
+	pluginProviders[providerId] = provider
+	return provider, nil
+}
@@ -37,9 +37,12 @@ type provider struct {
 	args        []arg
 }
 
+// envSecretBinding holds either a single provider binding or, in Bindings, several independent bindings
+// of the same secret key that are tried in order.
 type envSecretBinding struct {
-	Provider string            `json:"p"`
-	Ref      map[string][]byte `json:"r"`
+	Provider string              `json:"p"`
+	Ref      map[string][]byte   `json:"r"`
+	Bindings []*envSecretBinding `json:"b,omitempty"`
 }
 
 func (esb *envSecretBinding) string() (string, error) {
@@ -80,9 +83,23 @@ func registerDefaultProviders() {
 		Register(awsProviderId, awsProviderName, awsProviderDesc, bindWithAWSKMS, unBindFromAWSKMS, true, awsArgs)
 		Register(gcpProviderId, gcpProviderName, gcpProviderDesc, bindWithGCP, unBindWithGCP, true, gcpArgs)
 		Register(azureProviderId, azureProviderName, azureProviderDesc, bindWithAzure, unBindFromAzure, true, azureArgs)
+		Register(pkcs11ProviderId, pkcs11ProviderName, pkcs11ProviderDesc, bindWithPKCS11, unBindWithPKCS11, true, pkcs11Args)
+		Register(sshProviderId, sshProviderName, sshProviderDesc, bindWithSSH, unBindWithSSH, true, sshArgs)
+		Register(pgpProviderId, pgpProviderName, pgpProviderDesc, bindWithPGP, unBindWithPGP, true, pgpArgs)
+		Register(transitProviderId, transitProviderName, transitProviderDesc, bindWithTransit, unBindWithTransit, true, transitArgs)
+		Register(KeyringProviderId, keyringProviderName, keyringProviderDesc, bindWithKeyring, unBindWithKeyring, false, nil)
 	})
 }
 
+// getProvider returns the built-in provider with the given ID, falling back to a configured provider plugin.
+func getProvider(providerId string) (*provider, error) {
+	registerDefaultProviders()
+	if provider, ok := providerMap[providerId]; ok {
+		return provider, nil
+	}
+	return getPluginProvider(providerId)
+}
+
 func ListIds() []string {
 	registerDefaultProviders()
 	providerIds := make([]string, 0, len(providerMap))
@@ -116,17 +133,28 @@ func GetArgs(providerId string) []arg {
 	return nil
 }
 
+// IsBindingRequired reports whether the secret binding is all it takes to unbind the secret key of environments of
+// the provider. Providers that hold the secret key on the host, such as the OS keyring, can only be used for the
+// self environment, whose secret binding may even be left empty.
+func IsBindingRequired(providerId string) bool {
+	registerDefaultProviders()
+	if provider, ok := providerMap[providerId]; ok {
+		return provider.refRequired
+	}
+	return true
+}
+
 func NewEnv(providerId, envName string, envType environments.EnvType,
 	inputs map[string]string, quantumSafe bool) (*environments.Environment, error) {
-	registerDefaultProviders()
-	provider, ok := providerMap[providerId]
-	if !ok {
-		return nil, fmt.Errorf("unknown environment provider: %s", providerId)
+	provider, err := getProvider(providerId)
+	if err != nil {
+		return nil, err
 	}
-	for _, arg := range provider.args {
-		if arg.required && inputs[arg.id] == "" {
-			return nil, fmt.Errorf("missing required input: %s", arg.id)
-		}
+	if !provider.refRequired && envType != environments.USER {
+		return nil, fmt.Errorf("environment provider %s can only be used for user environments", providerId)
+	}
+	if err = validateProviderInputs(providerId, inputs); err != nil {
+		return nil, err
 	}
 	env, sk, err := environments.New(envName, envType, quantumSafe)
 	if err != nil {
@@ -140,7 +168,7 @@ func NewEnv(providerId, envName string, envType environments.EnvType,
 	if err != nil {
 		return nil, err
 	}
-	if provider.refRequired {
+	if provider.refRequired || len(ref) > 0 {
 		esb := &envSecretBinding{
 			Provider: providerId,
 			Ref:      ref,
@@ -160,30 +188,11 @@ func getSecretKeyFromBytesForBinding(skBytes []byte) (secretKey *crypto.SecretKe
 }
 
 func GetSecretKeyFromSecretBinding(envSecretBindingStr string) (secretKey *crypto.SecretKey, err error) {
-	registerDefaultProviders()
-	var secretKeyBytes []byte
-	var esb *envSecretBinding
-	if envSecretBindingStr == "" {
-		var providersWithoutRef []provider
-		for _, provider := range providerMap {
-			if !provider.refRequired {
-				providersWithoutRef = append(providersWithoutRef, *provider)
-			}
-		}
-		for _, provider := range providersWithoutRef {
-			if secretKeyBytes, err = (provider.unbind)(nil); err == nil {
-				return getSecretKeyFromBytesForBinding(secretKeyBytes)
-			}
-		}
-		return nil, errEnvSecretBindingUnspecified
-	} else if esb, err = envSecretBindingFromString(envSecretBindingStr); err == nil {
-		if provider, ok := providerMap[esb.Provider]; !ok {
-			return nil, fmt.Errorf("unknown environment provider: %s", esb.Provider)
-		} else if secretKeyBytes, err = (provider.unbind)(esb.Ref); err == nil {
-			return getSecretKeyFromBytesForBinding(secretKeyBytes)
-		}
+	secretKeyBytes, _, err := unbindSecretKey(envSecretBindingStr)
+	if err != nil {
+		return nil, err
 	}
-	return nil, err
+	return getSecretKeyFromBytesForBinding(secretKeyBytes)
 }
 
 type arg struct {
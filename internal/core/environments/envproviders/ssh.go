@@ -0,0 +1,176 @@
+package envproviders
+
+import (
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"net"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"slv.sh/slv/internal/core/commons"
+)
+
+const (
+	sshProviderId     = "ssh"
+	sshProviderName   = "SSH Agent"
+	sshProviderDesc   = "SSH key held in ssh-agent (only Ed25519 and RSA keys supported)"
+	sshPubKeyRefName  = "ssh-pubkey"
+	sshSaltRefName    = "salt"
+	sshSaltLength     = 32
+	sshChallengeInfo  = "slv-ssh-binding"
+	sshWrappingKeyLen = chacha20poly1305.KeySize
+
+	envar_SSH_AUTH_SOCK = "SSH_AUTH_SOCK"
+)
+
+var (
+	errSSHAgentNotAvailable = errors.New("ssh-agent not available: please start ssh-agent and set " + envar_SSH_AUTH_SOCK)
+	errSSHKeyNotInAgent     = errors.New("the SSH key is not loaded in ssh-agent")
+	errInvalidSSHPublicKey  = errors.New("invalid SSH public key")
+	errUnsupportedSSHKey    = errors.New("unsupported SSH key type: only Ed25519 and RSA keys produce deterministic signatures")
+	errInvalidSSHSignature  = errors.New("invalid signature from ssh-agent")
+
+	sshArgs = []arg{
+		{
+			id:          sshPubKeyRefName,
+			name:        "SSH Public Key",
+			required:    true,
+			description: "SSH public key (file path or authorized_keys line) or its SHA256 fingerprint as listed by ssh-add -l",
+		},
+	}
+)
+
+func connectSSHAgent() (net.Conn, error) {
+	socket := os.Getenv(envar_SSH_AUTH_SOCK)
+	if socket == "" {
+		return nil, errSSHAgentNotAvailable
+	}
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, errSSHAgentNotAvailable
+	}
+	return conn, nil
+}
+
+func isSupportedSSHKey(publicKey ssh.PublicKey) bool {
+	keyType := publicKey.Type()
+	return keyType == ssh.KeyAlgoED25519 || keyType == ssh.KeyAlgoRSA
+}
+
+// resolveSSHPublicKey parses the public key from a file, an authorized_keys line or a fingerprint of a key loaded in the agent.
+func resolveSSHPublicKey(sshAgent agent.ExtendedAgent, keyStr string) (ssh.PublicKey, error) {
+	if strings.HasPrefix(keyStr, "SHA256:") {
+		keys, err := sshAgent.List()
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			if ssh.FingerprintSHA256(key) == keyStr {
+				return ssh.ParsePublicKey(key.Marshal())
+			}
+		}
+		return nil, errSSHKeyNotInAgent
+	}
+	keyBytes := []byte(keyStr)
+	if commons.FileExists(keyStr) {
+		var err error
+		if keyBytes, err = os.ReadFile(keyStr); err != nil {
+			return nil, err
+		}
+	}
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey(keyBytes)
+	if err != nil {
+		return nil, errInvalidSSHPublicKey
+	}
+	return publicKey, nil
+}
+
+// deriveSSHWrappingKey has the agent sign a challenge made from the salt and derives the wrapping key from the signature.
+// Ed25519 and RSA PKCS#1 v1.5 signatures are deterministic, so the same key and salt always yield the same wrapping key.
+func deriveSSHWrappingKey(sshAgent agent.ExtendedAgent, publicKey ssh.PublicKey, salt []byte) ([]byte, error) {
+	if !isSupportedSSHKey(publicKey) {
+		return nil, errUnsupportedSSHKey
+	}
+	challenge := append([]byte(sshChallengeInfo+"\n"), salt...)
+	var flags agent.SignatureFlags
+	if publicKey.Type() == ssh.KeyAlgoRSA {
+		flags = agent.SignatureFlagRsaSha256
+	}
+	signature, err := sshAgent.SignWithFlags(publicKey, challenge, flags)
+	if err != nil {
+		return nil, errSSHKeyNotInAgent
+	}
+	if publicKey.Verify(challenge, signature) != nil {
+		return nil, errInvalidSSHSignature
+	}
+	return hkdf.Key(sha256.New, signature.Blob, salt, sshChallengeInfo, sshWrappingKeyLen)
+}
+
+func bindWithSSH(skBytes []byte, inputs map[string]string) (ref map[string][]byte, err error) {
+	conn, err := connectSSHAgent()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	sshAgent := agent.NewClient(conn)
+	publicKey, err := resolveSSHPublicKey(sshAgent, strings.TrimSpace(inputs[sshPubKeyRefName]))
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, sshSaltLength)
+	if _, err = rand.Read(salt); err != nil {
+		return nil, err
+	}
+	wrappingKey, err := deriveSSHWrappingKey(sshAgent, publicKey, salt)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(wrappingKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(skBytes)+aead.Overhead())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	ref = make(map[string][]byte)
+	ref[sshPubKeyRefName] = publicKey.Marshal()
+	ref[sshSaltRefName] = salt
+	ref[sealedSecretKeyRefName] = aead.Seal(nonce, nonce, skBytes, ref[sshPubKeyRefName])
+	return
+}
+
+func unBindWithSSH(ref map[string][]byte) (secretKeyBytes []byte, err error) {
+	publicKey, err := ssh.ParsePublicKey(ref[sshPubKeyRefName])
+	if err != nil {
+		return nil, errInvalidSSHPublicKey
+	}
+	sealedSecretKeyBytes := ref[sealedSecretKeyRefName]
+	if len(ref[sshSaltRefName]) != sshSaltLength || len(sealedSecretKeyBytes) < chacha20poly1305.NonceSizeX {
+		return nil, errSealedSecretKeyRef
+	}
+	conn, err := connectSSHAgent()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	sshAgent := agent.NewClient(conn)
+	wrappingKey, err := deriveSSHWrappingKey(sshAgent, publicKey, ref[sshSaltRefName])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(wrappingKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce, ciphertext := sealedSecretKeyBytes[:aead.NonceSize()], sealedSecretKeyBytes[aead.NonceSize():]
+	if secretKeyBytes, err = aead.Open(nil, nonce, ciphertext, ref[sshPubKeyRefName]); err != nil {
+		return nil, errSealedSecretKeyRef
+	}
+	return
+}
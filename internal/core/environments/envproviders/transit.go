@@ -0,0 +1,309 @@
+package envproviders
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	transitProviderId    = "vault-transit"
+	transitProviderName  = "HashiCorp Vault Transit"
+	transitProviderDesc  = "HashiCorp Vault Transit secrets engine"
+	transitAddressRef    = "address"
+	transitMountRef      = "mount"
+	transitKeyNameRef    = "key-name"
+	transitAuthRef       = "auth"
+	transitAuthMountRef  = "auth-mount"
+	transitRoleRef       = "role"
+	transitKeyVersionRef = "key-version"
+
+	transitDefaultMount     = "transit"
+	transitAuthToken        = "token"
+	transitAuthAppRole      = "approle"
+	transitAuthKubernetes   = "kubernetes"
+	transitTokenFileName    = ".vault-token"
+	transitK8sTokenPath     = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	transitRequestTimeout   = 30 * time.Second
+	transitCiphertextPrefix = `^vault:v([0-9]+):`
+
+	envar_VAULT_ADDR      = "VAULT_ADDR"
+	envar_VAULT_TOKEN     = "VAULT_TOKEN"
+	envar_VAULT_NAMESPACE = "VAULT_NAMESPACE"
+	envar_VAULT_CACERT    = "VAULT_CACERT"
+	envar_VAULT_ROLE_ID   = "VAULT_ROLE_ID"
+	envar_VAULT_SECRET_ID = "VAULT_SECRET_ID"
+)
+
+var (
+	errTransitAddressNotSet = errors.New("vault address not set: please specify the address or set " + envar_VAULT_ADDR)
+	errTransitInvalidRef    = errors.New("invalid Vault Transit binding: address, mount and key name are required")
+	errTransitInvalidAuth   = errors.New("invalid Vault auth method: must be token, approle or kubernetes")
+	errTransitTokenNotSet   = errors.New("vault token not set: please set " + envar_VAULT_TOKEN + " or log in with the vault CLI")
+	errTransitAppRoleNotSet = errors.New("vault AppRole credentials not set: please set " + envar_VAULT_ROLE_ID + " and " + envar_VAULT_SECRET_ID)
+	errTransitRoleNotSet    = errors.New("vault role required for kubernetes auth")
+	errTransitInvalidCACert = errors.New("invalid CA certificate in " + envar_VAULT_CACERT)
+
+	transitArgs = []arg{
+		{
+			id:          transitAddressRef,
+			name:        "Address",
+			required:    false,
+			description: "Address of the Vault server (defaults to " + envar_VAULT_ADDR + ")",
+		},
+		{
+			id:          transitMountRef,
+			name:        "Mount",
+			required:    false,
+			description: "Mount path of the Transit secrets engine (defaults to " + transitDefaultMount + ")",
+		},
+		{
+			id:          transitKeyNameRef,
+			name:        "Key Name",
+			required:    true,
+			description: "Name of the Transit key to wrap the environment secret key with",
+		},
+		{
+			id:          transitAuthRef,
+			name:        "Auth Method",
+			required:    false,
+			description: "Vault auth method: token (" + envar_VAULT_TOKEN + " or ~/" + transitTokenFileName + "), approle (" + envar_VAULT_ROLE_ID + " and " + envar_VAULT_SECRET_ID + ") or kubernetes (defaults to token)",
+		},
+		{
+			id:          transitAuthMountRef,
+			name:        "Auth Mount",
+			required:    false,
+			description: "Mount path of the approle or kubernetes auth method (defaults to the auth method name)",
+		},
+		{
+			id:          transitRoleRef,
+			name:        "Role",
+			required:    false,
+			description: "Vault role to log in with (required for kubernetes auth)",
+		},
+	}
+)
+
+type transitClient struct {
+	address    string
+	httpClient *http.Client
+	token      string
+}
+
+type transitResponse struct {
+	Errors []string        `json:"errors"`
+	Data   json.RawMessage `json:"data"`
+	Auth   *struct {
+		ClientToken string `json:"client_token"`
+	} `json:"auth"`
+}
+
+func newTransitClient(address string) (*transitClient, error) {
+	if address = strings.TrimRight(strings.TrimSpace(address), "/"); address == "" {
+		return nil, errTransitAddressNotSet
+	}
+	httpClient := &http.Client{Timeout: transitRequestTimeout}
+	if caCertPath := os.Getenv(envar_VAULT_CACERT); caCertPath != "" {
+		caCert, err := os.ReadFile(caCertPath)
+		if err != nil {
+			return nil, err
+		}
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(caCert) {
+			return nil, errTransitInvalidCACert
+		}
+		httpClient.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: certPool}}
+	}
+	return &transitClient{address: address, httpClient: httpClient}, nil
+}
+
+func (tc *transitClient) post(path string, body any) (*transitResponse, error) {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, tc.address+"/v1/"+path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if tc.token != "" {
+		req.Header.Set("X-Vault-Token", tc.token)
+	}
+	if namespace := os.Getenv(envar_VAULT_NAMESPACE); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+	resp, err := tc.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	response := new(transitResponse)
+	if err = json.NewDecoder(resp.Body).Decode(response); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("invalid response from vault: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if len(response.Errors) > 0 {
+			return nil, fmt.Errorf("vault request failed: %s", strings.Join(response.Errors, "; "))
+		}
+		return nil, fmt.Errorf("vault request failed with status code: %d", resp.StatusCode)
+	}
+	return response, nil
+}
+
+// login sets the client token for the auth method. Credentials are read from the environment at the time of use
+// and are never stored in the secret binding.
+func (tc *transitClient) login(authMethod, authMount, role string) error {
+	if authMount == "" {
+		authMount = authMethod
+	}
+	var loginRequest map[string]string
+	switch authMethod {
+	case "", transitAuthToken:
+		if tc.token = os.Getenv(envar_VAULT_TOKEN); tc.token == "" {
+			if homeDir, err := os.UserHomeDir(); err == nil {
+				if tokenBytes, err := os.ReadFile(filepath.Join(homeDir, transitTokenFileName)); err == nil {
+					tc.token = strings.TrimSpace(string(tokenBytes))
+				}
+			}
+		}
+		if tc.token == "" {
+			return errTransitTokenNotSet
+		}
+		return nil
+	case transitAuthAppRole:
+		roleId, secretId := os.Getenv(envar_VAULT_ROLE_ID), os.Getenv(envar_VAULT_SECRET_ID)
+		if roleId == "" || secretId == "" {
+			return errTransitAppRoleNotSet
+		}
+		loginRequest = map[string]string{"role_id": roleId, "secret_id": secretId}
+	case transitAuthKubernetes:
+		if role == "" {
+			return errTransitRoleNotSet
+		}
+		jwt, err := os.ReadFile(transitK8sTokenPath)
+		if err != nil {
+			return fmt.Errorf("failed to read the kubernetes service account token: %w", err)
+		}
+		loginRequest = map[string]string{"role": role, "jwt": strings.TrimSpace(string(jwt))}
+	default:
+		return errTransitInvalidAuth
+	}
+	response, err := tc.post("auth/"+strings.Trim(authMount, "/")+"/login", loginRequest)
+	if err != nil {
+		return err
+	}
+	if response.Auth == nil || response.Auth.ClientToken == "" {
+		return fmt.Errorf("vault %s login returned no client token", authMethod)
+	}
+	tc.token = response.Auth.ClientToken
+	return nil
+}
+
+func newTransitClientFromRef(ref map[string][]byte) (*transitClient, error) {
+	tc, err := newTransitClient(string(ref[transitAddressRef]))
+	if err != nil {
+		return nil, err
+	}
+	if err = tc.login(string(ref[transitAuthRef]), string(ref[transitAuthMountRef]), string(ref[transitRoleRef])); err != nil {
+		return nil, err
+	}
+	return tc, nil
+}
+
+func transitPath(ref map[string][]byte, operation string) string {
+	return strings.Trim(string(ref[transitMountRef]), "/") + "/" + operation + "/" + string(ref[transitKeyNameRef])
+}
+
+func bindWithTransit(skBytes []byte, inputs map[string]string) (ref map[string][]byte, err error) {
+	ref = make(map[string][]byte)
+	address := strings.TrimSpace(inputs[transitAddressRef])
+	if address == "" {
+		address = os.Getenv(envar_VAULT_ADDR)
+	}
+	mount := strings.TrimSpace(inputs[transitMountRef])
+	if mount == "" {
+		mount = transitDefaultMount
+	}
+	authMethod := strings.TrimSpace(inputs[transitAuthRef])
+	if authMethod == "" {
+		authMethod = transitAuthToken
+	}
+	ref[transitAddressRef] = []byte(strings.TrimRight(address, "/"))
+	ref[transitMountRef] = []byte(mount)
+	ref[transitKeyNameRef] = []byte(strings.TrimSpace(inputs[transitKeyNameRef]))
+	ref[transitAuthRef] = []byte(authMethod)
+	if authMount := strings.TrimSpace(inputs[transitAuthMountRef]); authMount != "" {
+		ref[transitAuthMountRef] = []byte(authMount)
+	}
+	if role := strings.TrimSpace(inputs[transitRoleRef]); role != "" {
+		ref[transitRoleRef] = []byte(role)
+	}
+	tc, err := newTransitClientFromRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	response, err := tc.post(transitPath(ref, "encrypt"), map[string]string{
+		"plaintext": base64.StdEncoding.EncodeToString(skBytes),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var data struct {
+		Ciphertext string `json:"ciphertext"`
+		KeyVersion int    `json:"key_version"`
+	}
+	if err = json.Unmarshal(response.Data, &data); err != nil || data.Ciphertext == "" {
+		return nil, errSealedSecretKeyRef
+	}
+	keyVersion := data.KeyVersion
+	// Older Vault releases do not return the key version; it is also recorded in the ciphertext.
+	if match := regexp.MustCompile(transitCiphertextPrefix).FindStringSubmatch(data.Ciphertext); keyVersion == 0 && match != nil {
+		keyVersion, _ = strconv.Atoi(match[1])
+	}
+	ref[transitKeyVersionRef] = []byte(strconv.Itoa(keyVersion))
+	ref[sealedSecretKeyRefName] = []byte(data.Ciphertext)
+	return
+}
+
+func unBindWithTransit(ref map[string][]byte) (secretKeyBytes []byte, err error) {
+	if len(ref[transitAddressRef]) == 0 || len(ref[transitMountRef]) == 0 || len(ref[transitKeyNameRef]) == 0 {
+		return nil, errTransitInvalidRef
+	}
+	sealedSecretKeyBytes := ref[sealedSecretKeyRefName]
+	if len(sealedSecretKeyBytes) == 0 {
+		return nil, errSealedSecretKeyRef
+	}
+	tc, err := newTransitClientFromRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	response, err := tc.post(transitPath(ref, "decrypt"), map[string]string{
+		"ciphertext": string(sealedSecretKeyBytes),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt with Vault Transit key %s (version %s): %w",
+			ref[transitKeyNameRef], ref[transitKeyVersionRef], err)
+	}
+	var data struct {
+		Plaintext string `json:"plaintext"`
+	}
+	if err = json.Unmarshal(response.Data, &data); err != nil {
+		return nil, errSealedSecretKeyRef
+	}
+	if secretKeyBytes, err = base64.StdEncoding.DecodeString(data.Plaintext); err != nil {
+		return nil, errSealedSecretKeyRef
+	}
+	return
+}
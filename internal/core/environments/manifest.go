@@ -10,6 +10,8 @@ type EnvManifest struct {
 	path         *string
 	Root         *Environment            `json:"root,omitempty" yaml:"root,omitempty"`
 	Environments map[string]*Environment `json:"environments,omitempty" yaml:"environments,omitempty"`
+	Admins       map[string]string       `json:"admins,omitempty" yaml:"admins,omitempty"`
+	Signatures   map[string]string       `json:"signatures,omitempty" yaml:"signatures,omitempty"`
 }
 
 func NewManifest(path string) (envManifest *EnvManifest, err error) {
@@ -72,6 +74,19 @@ func (envManifest *EnvManifest) GetEnv(id string) (env *Environment) {
 	return
 }
 
+// GetEnvByFingerprint returns the environment, including the root, whose public key has the given canonical fingerprint.
+func (envManifest *EnvManifest) GetEnvByFingerprint(fingerprint string) *Environment {
+	if envManifest.Root != nil && envManifest.Root.Fingerprint() == fingerprint {
+		return envManifest.Root
+	}
+	for _, env := range envManifest.Environments {
+		if env.Fingerprint() == fingerprint {
+			return env
+		}
+	}
+	return nil
+}
+
 func (envManifest *EnvManifest) searchEnvs(query string) (environments []*Environment) {
 	query = strings.ToLower(query)
 	for _, env := range envManifest.Environments {
@@ -100,6 +115,8 @@ func (envManifest *EnvManifest) DeleteEnv(id string) (env *Environment, err erro
 	}
 	env = envManifest.Environments[id]
 	delete(envManifest.Environments, id)
+	delete(envManifest.Signatures, id)
+	delete(envManifest.Admins, id)
 	return env, envManifest.write()
 }
 
@@ -0,0 +1,204 @@
+package environments
+
+import (
+	"slices"
+	"strings"
+
+	"slv.sh/slv/internal/core/crypto"
+)
+
+// signingData covers the details of the environment that others rely on when sharing with it. The secret binding
+// is left out, as it only matters to the environment itself and changes whenever it is rebound.
+func (env *Environment) signingData() []byte {
+	tags := slices.Clone(env.Tags)
+	slices.Sort(tags)
+	return []byte(strings.Join([]string{envSigningContext, env.PublicKey, env.Name, env.Email, string(env.EnvType),
+		env.SigningKey, strings.Join(tags, ",")}, "\n"))
+}
+
+func adminSigningData(env *Environment) []byte {
+	return []byte(strings.Join([]string{adminSigningContext, env.PublicKey, env.SigningKey}, "\n"))
+}
+
+// signAs signs the data with the secret key on behalf of the given environment, provided that the secret key
+// belongs to it.
+func signAs(env *Environment, secretKey *crypto.SecretKey, data []byte) (string, error) {
+	signingKey, err := env.GetSigningKey()
+	if err != nil {
+		return "", err
+	}
+	for _, pq := range []bool{false, true} {
+		if verificationKey, err := secretKey.VerificationKey(pq); err == nil && verificationKey.Equals(signingKey) {
+			signature, err := secretKey.Sign(data, pq)
+			if err != nil {
+				return "", err
+			}
+			return signature.String(), nil
+		}
+	}
+	return "", errNotRootOrAdmin
+}
+
+func verifySignature(signatureStr string, data []byte, signingKey *crypto.VerificationKey) bool {
+	signature, err := crypto.SignatureFromString(signatureStr)
+	return err == nil && signingKey.Verify(data, signature)
+}
+
+// IsSigned reports whether the manifest has a root environment with a signing key, in which case only the
+// environments signed by the root or one of its admins are to be trusted.
+func (envManifest *EnvManifest) IsSigned() bool {
+	return envManifest.Root != nil && envManifest.Root.SigningKey != ""
+}
+
+// admins returns the environments the root has delegated to sign environments, leaving out those whose
+// delegation does not match the signature of the root.
+func (envManifest *EnvManifest) admins() []*Environment {
+	rootSigningKey, err := envManifest.Root.GetSigningKey()
+	if err != nil {
+		return nil
+	}
+	var admins []*Environment
+	for publicKey, signature := range envManifest.Admins {
+		if env := envManifest.Environments[publicKey]; env != nil && verifySignature(signature, adminSigningData(env), rootSigningKey) {
+			admins = append(admins, env)
+		}
+	}
+	return admins
+}
+
+// ListAdmins returns the environments the root has delegated to sign environments.
+func (envManifest *EnvManifest) ListAdmins() []*Environment {
+	if !envManifest.IsSigned() {
+		return nil
+	}
+	return envManifest.admins()
+}
+
+// VerifyEnv returns an error unless the environment is the root, or is signed by the root or one of its admins.
+// Every environment is trusted in manifests that are not signed. An entry only counts as the root if it comes with
+// the public key and the signing key of the root, which the profile pins.
+func (envManifest *EnvManifest) VerifyEnv(env *Environment) error {
+	if !envManifest.IsSigned() || env == envManifest.Root ||
+		(env.PublicKey == envManifest.Root.PublicKey && env.SigningKey == envManifest.Root.SigningKey) {
+		return nil
+	}
+	signatureStr := envManifest.Signatures[env.PublicKey]
+	if signatureStr == "" {
+		return errEnvNotSigned
+	}
+	signature, err := crypto.SignatureFromString(signatureStr)
+	if err != nil {
+		return errEnvSignatureInvalid
+	}
+	for _, signer := range append([]*Environment{envManifest.Root}, envManifest.admins()...) {
+		signingKey, err := signer.GetSigningKey()
+		if err != nil || !signature.IsSignedBy(signingKey) {
+			continue
+		}
+		if signingKey.Verify(env.signingData(), signature) {
+			return nil
+		}
+		return errEnvSignatureInvalid
+	}
+	return errEnvSignerNotTrusted
+}
+
+// getSigner returns the root or the admin the first matching of the secret keys belongs to, along with that key.
+func (envManifest *EnvManifest) getSigner(secretKeys []*crypto.SecretKey) (*Environment, *crypto.SecretKey, error) {
+	if !envManifest.IsSigned() {
+		return nil, nil, errRootNotSet
+	}
+	signers := append([]*Environment{envManifest.Root}, envManifest.admins()...)
+	for _, secretKey := range secretKeys {
+		for _, signer := range signers {
+			if secretKey != nil && isSigningKeyOf(signer, secretKey) {
+				return signer, secretKey, nil
+			}
+		}
+	}
+	return nil, nil, errNotRootOrAdmin
+}
+
+func isSigningKeyOf(env *Environment, secretKey *crypto.SecretKey) bool {
+	signingKey, err := env.GetSigningKey()
+	if err != nil {
+		return false
+	}
+	for _, pq := range []bool{false, true} {
+		if verificationKey, err := secretKey.VerificationKey(pq); err == nil && verificationKey.Equals(signingKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// SignEnvs signs the environments with the given public keys, or all of them if none are given, with the first of
+// the secret keys that belongs to the root or an admin.
+func (envManifest *EnvManifest) SignEnvs(secretKeys []*crypto.SecretKey, publicKeys ...string) error {
+	signer, secretKey, err := envManifest.getSigner(secretKeys)
+	if err != nil {
+		return err
+	}
+	if len(publicKeys) == 0 {
+		for publicKey := range envManifest.Environments {
+			publicKeys = append(publicKeys, publicKey)
+		}
+	}
+	if envManifest.Signatures == nil {
+		envManifest.Signatures = make(map[string]string)
+	}
+	for _, publicKey := range publicKeys {
+		env := envManifest.Environments[publicKey]
+		if env == nil {
+			return errEnvNotFound
+		}
+		if envManifest.Signatures[publicKey], err = signAs(signer, secretKey, env.signingData()); err != nil {
+			return err
+		}
+	}
+	return envManifest.write()
+}
+
+// AddAdmin delegates the environment with the given public key to sign environments, which only the root can do
+// with one of the secret keys. The environment itself is signed along with it.
+func (envManifest *EnvManifest) AddAdmin(secretKeys []*crypto.SecretKey, publicKey string) error {
+	if !envManifest.IsSigned() {
+		return errRootNotSet
+	}
+	env := envManifest.Environments[publicKey]
+	if env == nil {
+		return errEnvNotFound
+	}
+	if _, err := env.GetSigningKey(); err != nil {
+		return err
+	}
+	var rootSecretKey *crypto.SecretKey
+	for _, secretKey := range secretKeys {
+		if secretKey != nil && isSigningKeyOf(envManifest.Root, secretKey) {
+			rootSecretKey = secretKey
+			break
+		}
+	}
+	if rootSecretKey == nil {
+		return errNotRoot
+	}
+	signature, err := signAs(envManifest.Root, rootSecretKey, adminSigningData(env))
+	if err != nil {
+		return err
+	}
+	if envManifest.Admins == nil {
+		envManifest.Admins = make(map[string]string)
+	}
+	envManifest.Admins[publicKey] = signature
+	return envManifest.SignEnvs([]*crypto.SecretKey{rootSecretKey}, publicKey)
+}
+
+// RemoveAdmin withdraws the delegation of the environment with the given public key to sign environments. The
+// environments it signed are no longer trusted until they are signed again.
+func (envManifest *EnvManifest) RemoveAdmin(publicKey string) error {
+	if _, found := envManifest.Admins[publicKey]; !found {
+		return errEnvNotFound
+	}
+	delete(envManifest.Admins, publicKey)
+	return envManifest.write()
+}
@@ -92,3 +92,19 @@ func Get(id []byte, useLocalStore bool) ([]byte, error) {
 		return nil, fmt.Errorf("error reading data from keystore: %w", err)
 	}
 }
+
+// Delete removes the data from the OS keyring and, if useLocalStore is set, from the local keystore.
+// Deleting data that is not found is not an error.
+func Delete(id []byte, useLocalStore bool) error {
+	sha256sum := sha256.Sum256(id)
+	storeId := commons.Encode(sha256sum[:])
+	if err := keyring.Delete(keyringServiceName, storeId); err != nil && err != keyring.ErrNotFound && !useLocalStore {
+		return fmt.Errorf("error deleting data from keystore: %w", err)
+	}
+	if useLocalStore {
+		if err := os.Remove(filepath.Join(keyStoreDir, storeId)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error deleting data from keystore: %w", err)
+		}
+	}
+	return nil
+}
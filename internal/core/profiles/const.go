@@ -2,6 +2,7 @@ package profiles
 
 import (
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -49,4 +50,15 @@ var (
 	errRemoteSetupNotImplemented        = errors.New("remote setup not implemented")
 	errRemotePullNotImplemented         = errors.New("remote pull not implemented")
 	errRemotePushNotSupported           = errors.New("remote push not supported")
+	errRemoteConflict                   = errors.New("the remote profile was changed by someone else since it was last pulled: please try again")
+	errProfileRootChanged               = errors.New("the root environment of the profile differs from the one it was first synced with: delete and add the profile again if the change is intended")
+	errChangesNotAllowed                = fmt.Errorf("%w: changing the profile", ErrNotAllowed)
+	errCreateEnvNotAllowed              = fmt.Errorf("%w: creating environments", ErrNotAllowed)
+	errVaultSharingNotAllowed           = fmt.Errorf("%w: sharing vaults with other environments", ErrNotAllowed)
+	errSettingsChangeNotAllowed         = fmt.Errorf("%w: only the root or an admin of the profile can change its settings", ErrNotAllowed)
+	errVaultDirDoesNotExist             = errors.New("vault directory does not exist")
+	errVaultDirNotRegistered            = errors.New("vault directory is not registered with the profile")
 )
+
+// ErrNotAllowed is wrapped by the errors returned when the settings of a profile do not allow an action.
+var ErrNotAllowed = errors.New("not allowed by the settings of the profile")
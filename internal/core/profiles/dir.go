@@ -0,0 +1,229 @@
+package profiles
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"slv.sh/slv/internal/core/commons"
+)
+
+const (
+	configDirPathKey    = "path"
+	configDirSymlinkKey = "symlink"
+	configDirWatchKey   = "watch"
+
+	dirRemoteStateFileName = ".dir-remote.yaml"
+	dirRemoteLockTimeout   = 30 * time.Second
+	dirWatchDebounce       = 500 * time.Millisecond
+)
+
+var dirArgs = []arg{
+	{
+		name:        configDirPathKey,
+		required:    true,
+		description: "Path to the directory holding the environments and settings manifests of the remote profile",
+	},
+	{
+		name:        configDirSymlinkKey,
+		description: "Set to true to symlink the manifests instead of copying them, so that changes are seen right away",
+	},
+	{
+		name:        configDirWatchKey,
+		description: "Set to true to sync running processes as soon as the manifests in the directory change",
+	},
+}
+
+// dirRemoteState records the hash of each manifest of the directory as it was last copied, so that push can tell
+// whether someone else changed it in the meantime.
+type dirRemoteState struct {
+	Hashes map[string]string `yaml:"hashes"`
+}
+
+func getDirRemoteState(dir string) *dirRemoteState {
+	state := new(dirRemoteState)
+	if err := commons.ReadFromYAML(filepath.Join(dir, dirRemoteStateFileName), state); err != nil || state.Hashes == nil {
+		state.Hashes = make(map[string]string)
+	}
+	return state
+}
+
+func (state *dirRemoteState) write(dir string) error {
+	return commons.WriteToYAML(filepath.Join(dir, dirRemoteStateFileName), state)
+}
+
+func getRemoteDir(cfg map[string]string) (string, error) {
+	remoteDir := cfg[configDirPathKey]
+	if remoteDir == "" || !commons.DirExists(remoteDir) {
+		return "", fmt.Errorf("remote profile directory does not exist: %s", remoteDir)
+	}
+	return remoteDir, nil
+}
+
+func isConfigEnabled(cfg map[string]string, key string) bool {
+	enabled, _ := strconv.ParseBool(cfg[key])
+	return enabled
+}
+
+// getRemoteManifestPath returns the path of the manifest in the remote directory, or the path it would be created
+// at if there is none yet.
+func getRemoteManifestPath(remoteDir string, fileNames []string) string {
+	if filePath := getAvailableFilePath(remoteDir, fileNames); filePath != "" {
+		return filePath
+	}
+	return filepath.Join(remoteDir, fileNames[0])
+}
+
+func dirSetup(dir string, cfg map[string]string) (err error) {
+	if cfg[configDirPathKey], err = filepath.Abs(cfg[configDirPathKey]); err != nil {
+		return err
+	}
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create profile data directory: %w", err)
+	}
+	if err = dirPull(dir, cfg); err != nil {
+		os.RemoveAll(dir)
+	}
+	return
+}
+
+func dirPull(dir string, cfg map[string]string) error {
+	remoteDir, err := getRemoteDir(cfg)
+	if err != nil {
+		return err
+	}
+	symlink := isConfigEnabled(cfg, configDirSymlinkKey)
+	state := &dirRemoteState{Hashes: make(map[string]string)}
+	for _, fileNames := range [][]string{envManifestFileNames, settingsFileNames} {
+		remotePath := getRemoteManifestPath(remoteDir, fileNames)
+		localPath := filepath.Join(dir, filepath.Base(remotePath))
+		for _, fileName := range fileNames {
+			if otherPath := filepath.Join(dir, fileName); otherPath != localPath {
+				os.Remove(otherPath)
+			}
+		}
+		if symlink {
+			if target, err := os.Readlink(localPath); err == nil && target == remotePath {
+				continue
+			}
+			os.Remove(localPath)
+			if err = os.Symlink(remotePath, localPath); err != nil {
+				return fmt.Errorf("failed to link %s: %w", remotePath, err)
+			}
+			continue
+		}
+		data, err := os.ReadFile(remotePath)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to read %s: %w", remotePath, err)
+		}
+		if err = compareAndWriteFile(localPath, data); err != nil {
+			return err
+		}
+		state.Hashes[filepath.Base(remotePath)] = hashManifest(data)
+	}
+	return state.write(dir)
+}
+
+// dirPush copies the manifests that changed to the directory. It is called with the remote locked, and fails if a
+// manifest in the directory no longer is the one last copied from it. Symlinked manifests are already written to.
+func dirPush(dir string, cfg map[string]string, note string) error {
+	remoteDir, err := getRemoteDir(cfg)
+	if err != nil {
+		return err
+	}
+	if isConfigEnabled(cfg, configDirSymlinkKey) {
+		return nil
+	}
+	state := getDirRemoteState(dir)
+	for _, fileNames := range [][]string{envManifestFileNames, settingsFileNames} {
+		localPath := getAvailableFilePath(dir, fileNames)
+		if localPath == "" {
+			continue
+		}
+		data, err := os.ReadFile(localPath)
+		if err != nil {
+			return err
+		}
+		fileName := filepath.Base(localPath)
+		remotePath := filepath.Join(remoteDir, fileName)
+		var remoteHash string
+		if remoteData, err := os.ReadFile(remotePath); err == nil {
+			remoteHash = hashManifest(remoteData)
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read %s: %w", remotePath, err)
+		}
+		if remoteHash != state.Hashes[fileName] {
+			return errRemoteConflict
+		}
+		hash := hashManifest(data)
+		if hash == remoteHash {
+			continue
+		}
+		if err = commons.WriteFileAtomic(remotePath, data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", remotePath, err)
+		}
+		state.Hashes[fileName] = hash
+	}
+	return state.write(dir)
+}
+
+// dirLock locks the directory against changes by other users until the returned function is called.
+func dirLock(cfg map[string]string) (unlock func(), err error) {
+	remoteDir, err := getRemoteDir(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return commons.LockFile(filepath.Join(remoteDir, defaultEnvManifestFileName), dirRemoteLockTimeout)
+}
+
+// dirWatch calls onChange whenever a manifest in the directory changes, if watching is enabled. Symlinked
+// manifests need no watching.
+func dirWatch(cfg map[string]string, onChange func()) error {
+	if !isConfigEnabled(cfg, configDirWatchKey) || isConfigEnabled(cfg, configDirSymlinkKey) {
+		return nil
+	}
+	remoteDir, err := getRemoteDir(cfg)
+	if err != nil {
+		return err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err = watcher.Add(remoteDir); err != nil {
+		watcher.Close()
+		return err
+	}
+	isManifest := func(name string) bool {
+		fileName := filepath.Base(name)
+		return slices.Contains(envManifestFileNames, fileName) || slices.Contains(settingsFileNames, fileName)
+	}
+	go func() {
+		var changed <-chan time.Time
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if isManifest(event.Name) {
+					changed = time.After(dirWatchDebounce)
+				}
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			case <-changed:
+				changed = nil
+				onChange()
+			}
+		}
+	}()
+	return nil
+}
@@ -2,18 +2,43 @@ package profiles
 
 import (
 	"os"
+	"strings"
 
+	"slv.sh/slv/internal/core/crypto"
 	"slv.sh/slv/internal/core/environments"
 )
 
-func (profile *Profile) getEnvManifestToWrite() (*environments.EnvManifest, error) {
+// updateRemote applies the change to the latest manifests of the remote and pushes them along with the note
+// returned by the change. The remote is kept locked meanwhile if it supports locking.
+func (profile *Profile) updateRemote(change func() (note string, err error)) error {
 	if !profile.IsPushSupported() {
-		return nil, errRemotePushNotSupported
+		return errRemotePushNotSupported
 	}
-	if err := profile.Pull(); err != nil {
-		return nil, err
+	unlock, err := profile.lockRemote()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err = profile.Pull(); err != nil {
+		return err
+	}
+	note, err := change()
+	if err != nil {
+		return err
 	}
-	return profile.getEnvManifest()
+	return profile.pushAndUndoOnError(note)
+}
+
+// updateEnvManifest applies the change to the latest environments manifest of the remote and pushes it along with
+// the note returned by the change.
+func (profile *Profile) updateEnvManifest(change func(envManifest *environments.EnvManifest) (note string, err error)) error {
+	return profile.updateRemote(func() (string, error) {
+		envManifest, err := profile.getEnvManifest()
+		if err != nil {
+			return "", err
+		}
+		return change(envManifest)
+	})
 }
 
 func (profile *Profile) pushAndUndoOnError(note string) error {
@@ -27,25 +52,26 @@ func (profile *Profile) pushAndUndoOnError(note string) error {
 }
 
 func (profile *Profile) PutEnv(env *environments.Environment) error {
-	envManifest, err := profile.getEnvManifestToWrite()
-	if err != nil {
-		return err
-	}
-	if err = envManifest.PutEnv(env); err != nil {
-		return err
-	}
-	return profile.pushAndUndoOnError("Adding environment: " + env.PublicKey + " [" + env.Name + "]")
+	return profile.updateEnvManifest(func(envManifest *environments.EnvManifest) (string, error) {
+		if err := profile.checkChanges(); err != nil {
+			return "", err
+		}
+		if envManifest.GetEnv(env.PublicKey) == nil {
+			if err := profile.CheckCreateEnv(); err != nil {
+				return "", err
+			}
+		}
+		return "Adding environment: " + env.PublicKey + " [" + env.Name + "]", envManifest.PutEnv(env)
+	})
 }
 
 func (profile *Profile) SetRoot(env *environments.Environment) error {
-	envManifest, err := profile.getEnvManifestToWrite()
-	if err != nil {
-		return err
-	}
-	if err = envManifest.SetRoot(env); err != nil {
-		return err
-	}
-	return profile.pushAndUndoOnError("Setting root environment: " + env.PublicKey + " [" + env.Name + "]")
+	return profile.updateEnvManifest(func(envManifest *environments.EnvManifest) (string, error) {
+		if err := profile.checkChanges(); err != nil {
+			return "", err
+		}
+		return "Setting root environment: " + env.PublicKey + " [" + env.Name + "]", envManifest.SetRoot(env)
+	})
 }
 
 func (profile *Profile) GetRoot() (*environments.Environment, error) {
@@ -65,15 +91,16 @@ func (profile *Profile) SearchEnvs(queries []string) ([]*environments.Environmen
 }
 
 func (profile *Profile) DeleteEnv(id string) error {
-	envManifest, err := profile.getEnvManifestToWrite()
-	if err != nil {
-		return err
-	}
-	var env *environments.Environment
-	if env, err = envManifest.DeleteEnv(id); err != nil {
-		return err
-	}
-	return profile.pushAndUndoOnError("Removing environment: " + env.PublicKey + " [" + env.Name + "]")
+	return profile.updateEnvManifest(func(envManifest *environments.EnvManifest) (string, error) {
+		if err := profile.checkChanges(); err != nil {
+			return "", err
+		}
+		env, err := envManifest.DeleteEnv(id)
+		if err != nil {
+			return "", err
+		}
+		return "Removing environment: " + env.PublicKey + " [" + env.Name + "]", nil
+	})
 }
 
 func (profile *Profile) ListEnvs() ([]*environments.Environment, error) {
@@ -91,3 +118,68 @@ func (profile *Profile) GetEnv(id string) (*environments.Environment, error) {
 	}
 	return envManifest.GetEnv(id), nil
 }
+
+// GetEnvByFingerprint resolves a public key fingerprint to an environment of the profile.
+// It returns nil if no environment of the profile has the given fingerprint.
+func (profile *Profile) GetEnvByFingerprint(fingerprint string) (*environments.Environment, error) {
+	fingerprint, err := crypto.ParseFingerprint(fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	envManifest, err := profile.getEnvManifest()
+	if err != nil {
+		return nil, err
+	}
+	return envManifest.GetEnvByFingerprint(fingerprint), nil
+}
+
+// IsSigned reports whether the environments of the profile must be signed by its root or one of its admins.
+func (profile *Profile) IsSigned() (bool, error) {
+	envManifest, err := profile.getEnvManifest()
+	if err != nil {
+		return false, err
+	}
+	return envManifest.IsSigned(), nil
+}
+
+// VerifyEnv returns an error unless the environment is signed by the root or an admin of the profile, or the
+// profile does not sign its environments.
+func (profile *Profile) VerifyEnv(env *environments.Environment) error {
+	envManifest, err := profile.getEnvManifest()
+	if err != nil {
+		return err
+	}
+	return envManifest.VerifyEnv(env)
+}
+
+// SignEnvs signs the environments with the given public keys, or all of them if none are given, using the first of
+// the secret keys that belongs to the root or an admin of the profile.
+func (profile *Profile) SignEnvs(secretKeys []*crypto.SecretKey, publicKeys ...string) error {
+	return profile.updateEnvManifest(func(envManifest *environments.EnvManifest) (string, error) {
+		note := "Signing all environments"
+		if len(publicKeys) > 0 {
+			note = "Signing environments: " + strings.Join(publicKeys, ", ")
+		}
+		return note, envManifest.SignEnvs(secretKeys, publicKeys...)
+	})
+}
+
+func (profile *Profile) ListAdmins() ([]*environments.Environment, error) {
+	envManifest, err := profile.getEnvManifest()
+	if err != nil {
+		return nil, err
+	}
+	return envManifest.ListAdmins(), nil
+}
+
+func (profile *Profile) AddAdmin(secretKeys []*crypto.SecretKey, env *environments.Environment) error {
+	return profile.updateEnvManifest(func(envManifest *environments.EnvManifest) (string, error) {
+		return "Adding admin: " + env.PublicKey + " [" + env.Name + "]", envManifest.AddAdmin(secretKeys, env.PublicKey)
+	})
+}
+
+func (profile *Profile) RemoveAdmin(env *environments.Environment) error {
+	return profile.updateEnvManifest(func(envManifest *environments.EnvManifest) (string, error) {
+		return "Removing admin: " + env.PublicKey + " [" + env.Name + "]", envManifest.RemoveAdmin(env.PublicKey)
+	})
+}
@@ -2,6 +2,9 @@ package profiles
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -15,6 +18,9 @@ import (
 const (
 	configHTTPUrlKey    = "url"
 	configHTTPHeaderKey = "auth-header"
+
+	httpRemoteStateFileName = ".http-remote.yaml"
+	httpNoteHeader          = "X-SLV-Note"
 )
 
 var httpArgs = []arg{
@@ -46,32 +52,69 @@ func compareAndWriteFile(filePath string, data []byte) (err error) {
 	return
 }
 
-func httpPull(dir string, cfg map[string]string) (err error) {
-	url := strings.TrimSuffix(cfg[configHTTPUrlKey], "/")
+// httpRemoteState records the ETag of each manifest as it was last pulled or pushed, along with a hash of its
+// contents, so that push can tell which manifests changed and whether someone else changed them in the meantime.
+type httpRemoteState struct {
+	Files map[string]*httpRemoteFile `yaml:"files"`
+}
+
+type httpRemoteFile struct {
+	ETag string `yaml:"etag,omitempty"`
+	Hash string `yaml:"hash"`
+}
+
+func getHTTPRemoteState(dir string) *httpRemoteState {
+	state := new(httpRemoteState)
+	if err := commons.ReadFromYAML(filepath.Join(dir, httpRemoteStateFileName), state); err != nil || state.Files == nil {
+		state.Files = make(map[string]*httpRemoteFile)
+	}
+	return state
+}
+
+func (state *httpRemoteState) write(dir string) error {
+	return commons.WriteToYAML(filepath.Join(dir, httpRemoteStateFileName), state)
+}
+
+func hashManifest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func getHTTPRemote(cfg map[string]string) (url string, headers map[string]string, err error) {
+	url = strings.TrimSuffix(cfg[configHTTPUrlKey], "/")
 	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
-		return fmt.Errorf("invalid HTTP URL: %s", url)
+		return "", nil, fmt.Errorf("invalid HTTP URL: %s", url)
 	}
 	header := cfg[configHTTPHeaderKey]
-	headers := make(map[string]string)
+	headers = make(map[string]string)
 	headers["User-Agent"] = config.AppNameUpperCase + "-" + config.Version + " (" + runtime.GOOS + "/" + runtime.GOARCH + ")"
 	if header != "" {
 		parts := strings.SplitN(header, ":", 2)
 		if len(parts) != 2 {
-			return fmt.Errorf("invalid header format: %s", header)
+			return "", nil, fmt.Errorf("invalid header format: %s", header)
 		}
 		headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
 	}
+	return url, headers, nil
+}
+
+func httpPull(dir string, cfg map[string]string) (err error) {
+	url, headers, err := getHTTPRemote(cfg)
+	if err != nil {
+		return err
+	}
 	var envManifestFileName, settingsFileName string
 	var environmentBytes, settingsBytes []byte
+	var environmentETag, settingsETag string
 	for _, envManifestFileName = range envManifestFileNames {
-		if environmentBytes, err = commons.GetURLContents(url+"/"+envManifestFileName, headers); err != nil {
+		if environmentBytes, environmentETag, err = commons.GetURLContentsWithETag(url+"/"+envManifestFileName, headers); err != nil {
 			return fmt.Errorf("failed to get environments manifest: %w", err)
 		} else if len(environmentBytes) > 0 {
 			break
 		}
 	}
 	for _, settingsFileName = range settingsFileNames {
-		if settingsBytes, err = commons.GetURLContents(url+"/"+settingsFileName, headers); err != nil {
+		if settingsBytes, settingsETag, err = commons.GetURLContentsWithETag(url+"/"+settingsFileName, headers); err != nil {
 			return fmt.Errorf("failed to get settings manifest: %w", err)
 		} else if len(settingsBytes) > 0 {
 			break
@@ -80,17 +123,61 @@ func httpPull(dir string, cfg map[string]string) (err error) {
 	if err = os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("failed to create profile directory: %w", err)
 	}
+	state := &httpRemoteState{Files: make(map[string]*httpRemoteFile)}
 	if len(environmentBytes) > 0 {
 		if err = compareAndWriteFile(filepath.Join(dir, envManifestFileName), environmentBytes); err != nil {
 			return fmt.Errorf("failed to write environments manifest: %w", err)
 		}
+		state.Files[envManifestFileName] = &httpRemoteFile{ETag: environmentETag, Hash: hashManifest(environmentBytes)}
 	}
 	if len(settingsBytes) > 0 {
 		if err = compareAndWriteFile(filepath.Join(dir, settingsFileName), settingsBytes); err != nil {
 			return fmt.Errorf("failed to write settings manifest: %w", err)
 		}
+		state.Files[settingsFileName] = &httpRemoteFile{ETag: settingsETag, Hash: hashManifest(settingsBytes)}
+	}
+	return state.write(dir)
+}
+
+// httpPush uploads the manifests that changed since they were last pulled with PUT. Each upload is conditional on
+// the ETag the manifest was pulled with, or on the manifest not existing yet, so that changes made by someone else
+// in the meantime are never overwritten. Servers that send no ETags are written to unconditionally.
+func httpPush(dir string, cfg map[string]string, note string) (err error) {
+	url, headers, err := getHTTPRemote(cfg)
+	if err != nil {
+		return err
+	}
+	if note != "" {
+		headers[httpNoteHeader] = note
+	}
+	state := getHTTPRemoteState(dir)
+	for _, fileNames := range [][]string{envManifestFileNames, settingsFileNames} {
+		filePath := getAvailableFilePath(dir, fileNames)
+		if filePath == "" {
+			continue
+		}
+		var data []byte
+		if data, err = os.ReadFile(filePath); err != nil {
+			return err
+		}
+		fileName := filepath.Base(filePath)
+		hash := hashManifest(data)
+		etag := "*"
+		if remoteFile := state.Files[fileName]; remoteFile != nil {
+			if remoteFile.Hash == hash {
+				continue
+			}
+			etag = remoteFile.ETag
+		}
+		if etag, err = commons.PutURLContents(url+"/"+fileName, headers, data, etag); err != nil {
+			if errors.Is(err, commons.ErrPreconditionFailed) {
+				return errRemoteConflict
+			}
+			return fmt.Errorf("failed to upload %s: %w", fileName, err)
+		}
+		state.Files[fileName] = &httpRemoteFile{ETag: etag, Hash: hash}
 	}
-	return nil
+	return state.write(dir)
 }
 
 func httpSetup(dir string, config map[string]string) (err error) {
@@ -0,0 +1,135 @@
+package profiles
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+	"slv.sh/slv/internal/core/commons"
+)
+
+const httpServerMaxManifestSize = 16 << 20
+
+// HTTPRemoteServer serves the environments and settings manifests of a directory as a writable http remote.
+// Uploads are only accepted if they are based on the current contents of the manifest, as given by the If-Match
+// or If-None-Match headers. Uploads without either header are rejected rather than overwriting the manifest.
+type HTTPRemoteServer struct {
+	dir         string
+	headerName  string
+	headerValue string
+	mutex       sync.Mutex
+	onUpdate    func(fileName, note string)
+}
+
+// NewHTTPRemoteServer returns a server for the manifests in the given directory. Requests must carry the given
+// authentication header (e.g. 'Authorization: Bearer <token>') unless it is empty. onUpdate, if not nil, is
+// called whenever a manifest has been updated.
+func NewHTTPRemoteServer(dir, authHeader string, onUpdate func(fileName, note string)) (*HTTPRemoteServer, error) {
+	if !commons.DirExists(dir) {
+		return nil, errProfilePathDoesNotExist
+	}
+	server := &HTTPRemoteServer{dir: dir, onUpdate: onUpdate}
+	if authHeader != "" {
+		parts := strings.SplitN(authHeader, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid header format: %s", authHeader)
+		}
+		server.headerName = strings.TrimSpace(parts[0])
+		server.headerValue = strings.TrimSpace(parts[1])
+	}
+	return server, nil
+}
+
+func manifestETag(data []byte) string {
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+func (server *HTTPRemoteServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if server.headerName != "" &&
+		subtle.ConstantTimeCompare([]byte(r.Header.Get(server.headerName)), []byte(server.headerValue)) != 1 {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	fileName := strings.TrimPrefix(r.URL.Path, "/")
+	if !slices.Contains(envManifestFileNames, fileName) && !slices.Contains(settingsFileNames, fileName) {
+		http.NotFound(w, r)
+		return
+	}
+	filePath := filepath.Join(server.dir, fileName)
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		data, err := os.ReadFile(filePath)
+		if errors.Is(err, os.ErrNotExist) {
+			http.NotFound(w, r)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Header().Set("ETag", manifestETag(data))
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+	case http.MethodPut:
+		server.put(w, r, fileName, filePath)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func (server *HTTPRemoteServer) put(w http.ResponseWriter, r *http.Request, fileName, filePath string) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, httpServerMaxManifestSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	var manifest map[string]any
+	if err = yaml.Unmarshal(data, &manifest); err != nil {
+		http.Error(w, "invalid manifest: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	current, err := os.ReadFile(filePath)
+	exists := err == nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	ifMatch, ifNoneMatch := r.Header.Get("If-Match"), r.Header.Get("If-None-Match")
+	if ifMatch == "" && ifNoneMatch == "" {
+		http.Error(w, http.StatusText(http.StatusPreconditionRequired), http.StatusPreconditionRequired)
+		return
+	}
+	if (ifNoneMatch == "*" && exists) ||
+		(ifMatch != "" && (!exists || (ifMatch != "*" && ifMatch != manifestETag(current)))) {
+		http.Error(w, http.StatusText(http.StatusPreconditionFailed), http.StatusPreconditionFailed)
+		return
+	}
+	if err = commons.WriteFileAtomic(filePath, data, 0644); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if server.onUpdate != nil {
+		server.onUpdate(fileName, r.Header.Get(httpNoteHeader))
+	}
+	w.Header().Set("ETag", manifestETag(data))
+	if exists {
+		w.WriteHeader(http.StatusNoContent)
+	} else {
+		w.WriteHeader(http.StatusCreated)
+	}
+}
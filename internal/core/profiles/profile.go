@@ -13,12 +13,15 @@ import (
 )
 
 type profileConfig struct {
-	RemoteType   string            `json:"type" yaml:"type"`
-	ReadOnly     bool              `json:"readOnly" yaml:"readOnly"`
-	SyncedAt     time.Time         `json:"syncedAt" yaml:"syncedAt"`
-	SyncInterval time.Duration     `json:"syncInterval" yaml:"syncInterval"`
-	Config       map[string]string `json:"config" yaml:"config"`
-	file         string
+	RemoteType    string            `json:"type" yaml:"type"`
+	ReadOnly      bool              `json:"readOnly" yaml:"readOnly"`
+	SyncedAt      time.Time         `json:"syncedAt" yaml:"syncedAt"`
+	SyncInterval  time.Duration     `json:"syncInterval" yaml:"syncInterval"`
+	Config        map[string]string `json:"config" yaml:"config"`
+	VaultDirs     []string          `json:"vaultDirs,omitempty" yaml:"vaultDirs,omitempty"`
+	RootKey       string            `json:"rootKey,omitempty" yaml:"rootKey,omitempty"`
+	RootPublicKey string            `json:"rootPublicKey,omitempty" yaml:"rootPublicKey,omitempty"`
+	file          string
 }
 
 func (pc *profileConfig) decrypt() error {
@@ -136,12 +139,43 @@ func (profile *Profile) pullOnDue() error {
 	if err != nil {
 		return err
 	}
-	if commons.DirExists(profile.dataDir) && time.Since(profConfig.SyncedAt) < profConfig.SyncInterval {
+	if commons.DirExists(profile.dataDir) && time.Since(profConfig.SyncedAt) < profile.getSyncInterval(profConfig) {
 		return nil
 	}
 	return profile.Pull()
 }
 
+// lockRemote locks the remote against changes by other users if it supports locking.
+func (profile *Profile) lockRemote() (unlock func(), err error) {
+	remote, err := profile.getRemote()
+	if err != nil {
+		return nil, err
+	}
+	if remote.lock == nil {
+		return func() {}, nil
+	}
+	profConfig, err := profile.getConfig()
+	if err != nil {
+		return nil, err
+	}
+	return (remote.lock)(profConfig.Config)
+}
+
+// watchRemote syncs the profile whenever the remote changes, if the remote supports watching.
+func (profile *Profile) watchRemote() error {
+	remote, err := profile.getRemote()
+	if err != nil || remote.watch == nil {
+		return err
+	}
+	profConfig, err := profile.getConfig()
+	if err != nil {
+		return err
+	}
+	return (remote.watch)(profConfig.Config, func() {
+		profile.Pull()
+	})
+}
+
 func (profile *Profile) IsPushSupported() bool {
 	if profConfig, err := profile.getConfig(); err == nil && profConfig.ReadOnly {
 		return false
@@ -233,6 +267,9 @@ func getProfile(name, dir string) (profile *Profile, err error) {
 	if err = profile.pullOnDue(); err != nil {
 		return nil, err
 	}
+	if err = profile.watchRemote(); err != nil {
+		return nil, err
+	}
 	return
 }
 
@@ -271,6 +308,38 @@ func (profile *Profile) getEnvManifest() (*environments.EnvManifest, error) {
 		if err != nil {
 			return nil, err
 		}
+		if err = profile.checkRoot(); err != nil {
+			profile.envManifest = nil
+			return nil, err
+		}
 	}
 	return profile.envManifest, nil
 }
+
+// checkRoot pins the public key and the signing key of the root environment the first time the environments manifest
+// has a root, and fails if the manifest later comes with a different root, as it could otherwise be swapped along
+// with the signatures and the access it vouches for. Profiles pinned by earlier releases only hold the signing key,
+// so the public key is pinned along with it as long as the signing key matches.
+func (profile *Profile) checkRoot() error {
+	profConfig, err := profile.getConfig()
+	if err != nil {
+		return err
+	}
+	var rootPublicKey, rootKey string
+	if root := profile.envManifest.Root; root != nil {
+		rootPublicKey = root.PublicKey
+		rootKey = root.SigningKey
+	}
+	if profConfig.RootPublicKey == "" && profConfig.RootKey == "" {
+		if rootPublicKey == "" {
+			return nil
+		}
+	} else if rootKey != profConfig.RootKey || (profConfig.RootPublicKey != "" && rootPublicKey != profConfig.RootPublicKey) {
+		return errProfileRootChanged
+	} else if profConfig.RootPublicKey != "" {
+		return nil
+	}
+	profConfig.RootPublicKey = rootPublicKey
+	profConfig.RootKey = rootKey
+	return profConfig.write()
+}
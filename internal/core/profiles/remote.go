@@ -20,7 +20,10 @@ func RegisterRemote(name string, setup setup, pull pull, push push, args []arg)
 func registerDefaultRemotes() {
 	remoteInitializer.Do(func() {
 		RegisterRemote("git", gitSetup, gitPull, gitPush, gitArgs)
-		RegisterRemote("http", httpSetup, httpPull, nil, httpArgs)
+		RegisterRemote("http", httpSetup, httpPull, httpPush, httpArgs)
+		RegisterRemote("dir", dirSetup, dirPull, dirPush, dirArgs)
+		remotes["dir"].lock = dirLock
+		remotes["dir"].watch = dirWatch
 	})
 }
 
@@ -44,11 +47,20 @@ type setup func(dir string, config map[string]string) error
 type pull func(dir string, config map[string]string) error
 type push func(dir string, config map[string]string, note string) error
 
+// lock keeps other users from changing the remote until unlock is called. Remotes that detect conflicting
+// changes by other means need no lock.
+type lock func(config map[string]string) (unlock func(), err error)
+
+// watch calls onChange whenever the remote changes, for as long as the process runs.
+type watch func(config map[string]string, onChange func()) error
+
 type remote struct {
 	name  string
 	setup setup
 	pull  pull
 	push  push
+	lock  lock
+	watch watch
 	args  []arg
 }
 
@@ -0,0 +1,111 @@
+package profiles
+
+import (
+	"time"
+
+	"slv.sh/slv/internal/core/crypto"
+	"slv.sh/slv/internal/core/settings"
+)
+
+var sessionSecretKeys func() ([]*crypto.SecretKey, error)
+
+// SetSessionSecretKeys sets the function that returns the secret keys of the current session, which tells whether
+// the session belongs to the root or an admin of a profile. It is set by the session package, which depends on
+// this one.
+func SetSessionSecretKeys(getSecretKeys func() ([]*crypto.SecretKey, error)) {
+	sessionSecretKeys = getSecretKeys
+}
+
+// IsRootOrAdmin reports whether the current session belongs to the root or an admin of the profile, who are not
+// bound by the settings of the profile.
+func (profile *Profile) IsRootOrAdmin() bool {
+	if sessionSecretKeys == nil {
+		return false
+	}
+	envManifest, err := profile.getEnvManifest()
+	if err != nil || envManifest.Root == nil {
+		return false
+	}
+	secretKeys, err := sessionSecretKeys()
+	if err != nil {
+		return false
+	}
+	privilegedEnvs := append(envManifest.ListAdmins(), envManifest.Root)
+	for _, secretKey := range secretKeys {
+		for _, pq := range []bool{false, true} {
+			publicKey, err := secretKey.PublicKey(pq)
+			if err != nil {
+				continue
+			}
+			publicKeyStr, err := publicKey.String()
+			if err != nil {
+				continue
+			}
+			for _, env := range privilegedEnvs {
+				if env.PublicKey == publicKeyStr {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (profile *Profile) checkSetting(allowed func(*settings.Settings) bool, errNotAllowed error) error {
+	profileSettings, err := profile.GetSettings()
+	if err != nil {
+		return err
+	}
+	if allowed(profileSettings) || profile.IsRootOrAdmin() {
+		return nil
+	}
+	return errNotAllowed
+}
+
+func (profile *Profile) checkChanges() error {
+	return profile.checkSetting(func(s *settings.Settings) bool { return s.AllowChanges }, errChangesNotAllowed)
+}
+
+// CheckCreateEnv returns an error if the settings of the profile do not allow the current session to create
+// environments.
+func (profile *Profile) CheckCreateEnv() error {
+	return profile.checkSetting(func(s *settings.Settings) bool { return s.AllowCreateEnv }, errCreateEnvNotAllowed)
+}
+
+// CheckVaultSharing returns an error if the settings of the profile do not allow the current session to share
+// vaults with other environments.
+func (profile *Profile) CheckVaultSharing() error {
+	return profile.checkSetting(func(s *settings.Settings) bool { return s.AllowVaultSharing }, errVaultSharingNotAllowed)
+}
+
+// SetSetting changes a setting of the profile and pushes it. Once the profile has a root, only the root and its
+// admins can change the settings; until then the settings must allow changes.
+func (profile *Profile) SetSetting(key, value string) error {
+	return profile.updateRemote(func() (string, error) {
+		root, err := profile.GetRoot()
+		if err != nil {
+			return "", err
+		}
+		if root != nil && !profile.IsRootOrAdmin() {
+			return "", errSettingsChangeNotAllowed
+		} else if err = profile.checkChanges(); err != nil {
+			return "", err
+		}
+		profileSettings, err := profile.GetSettings()
+		if err != nil {
+			return "", err
+		}
+		return "Setting " + key + " to " + value, profileSettings.Set(key, value)
+	})
+}
+
+// getSyncInterval returns the interval the profile is synced at, which the settings of the profile can shorten.
+func (profile *Profile) getSyncInterval(profConfig *profileConfig) time.Duration {
+	syncInterval := profConfig.SyncInterval
+	if profileSettings, err := profile.GetSettings(); err == nil && profileSettings.SyncInterval > 0 {
+		if maxSyncInterval := time.Duration(profileSettings.SyncInterval) * time.Second; maxSyncInterval < syncInterval {
+			syncInterval = maxSyncInterval
+		}
+	}
+	return syncInterval
+}
@@ -0,0 +1,53 @@
+package profiles
+
+import (
+	"path/filepath"
+	"slices"
+
+	"slv.sh/slv/internal/core/commons"
+)
+
+// GetVaultDirs returns the local directories registered with the profile as locations holding vaults.
+func (profile *Profile) GetVaultDirs() ([]string, error) {
+	profConfig, err := profile.getConfig()
+	if err != nil {
+		return nil, err
+	}
+	return profConfig.VaultDirs, nil
+}
+
+// AddVaultDir registers a local directory with the profile as a location holding vaults.
+func (profile *Profile) AddVaultDir(dir string) error {
+	profConfig, err := profile.getConfig()
+	if err != nil {
+		return err
+	}
+	if dir, err = filepath.Abs(dir); err != nil {
+		return err
+	}
+	if !commons.DirExists(dir) {
+		return errVaultDirDoesNotExist
+	}
+	if slices.Contains(profConfig.VaultDirs, dir) {
+		return nil
+	}
+	profConfig.VaultDirs = append(profConfig.VaultDirs, dir)
+	return profConfig.write()
+}
+
+// RemoveVaultDir unregisters a directory from the profile.
+func (profile *Profile) RemoveVaultDir(dir string) error {
+	profConfig, err := profile.getConfig()
+	if err != nil {
+		return err
+	}
+	if absDir, err := filepath.Abs(dir); err == nil {
+		dir = absDir
+	}
+	index := slices.Index(profConfig.VaultDirs, dir)
+	if index < 0 {
+		return errVaultDirNotRegistered
+	}
+	profConfig.VaultDirs = slices.Delete(profConfig.VaultDirs, index, index+1)
+	return profConfig.write()
+}
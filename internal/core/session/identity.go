@@ -0,0 +1,124 @@
+package session
+
+import (
+	"strings"
+	"sync"
+
+	"slv.sh/slv/internal/core/crypto"
+	"slv.sh/slv/internal/core/environments"
+	"slv.sh/slv/internal/core/profiles"
+)
+
+const (
+	SourceSecretKey     = "secret key"
+	SourceSecretBinding = "secret binding"
+	SourceAgent         = "agent"
+	SourceSelf          = "self"
+	SourceK8s           = "k8s"
+)
+
+func init() {
+	profiles.SetSessionSecretKeys(GetSecretKeys)
+}
+
+// Identity is a secret key the session can act with, along with the environment it belongs to.
+type Identity struct {
+	secretKey   *crypto.SecretKey
+	source      string
+	env         *environments.Environment
+	pubKeyEC    string
+	pubKeyPQ    string
+	initialized bool
+	initMutex   sync.Mutex
+}
+
+func newIdentity(secretKey *crypto.SecretKey, source string) *Identity {
+	return &Identity{secretKey: secretKey, source: source}
+}
+
+func (id *Identity) SecretKey() *crypto.SecretKey {
+	return id.secretKey
+}
+
+// Source returns where the secret key of the identity was loaded from.
+func (id *Identity) Source() string {
+	return id.source
+}
+
+// Env returns the environment of the identity from the active profile, or nil if it is not found.
+func (id *Identity) Env() (*environments.Environment, error) {
+	if !id.initialized && id.secretKey != nil {
+		id.initMutex.Lock()
+		defer id.initMutex.Unlock()
+		if !id.initialized {
+			profile, err := profiles.GetActiveProfile()
+			if err != nil {
+				return nil, err
+			}
+			if id.env == nil {
+				if env, err := profile.GetEnv(id.PublicKeyEC()); err == nil {
+					id.env = env
+				}
+				if id.env == nil {
+					if env, err := profile.GetEnv(id.PublicKeyPQ()); err == nil {
+						id.env = env
+					}
+				}
+			}
+			id.initialized = true
+		}
+	}
+	return id.env, nil
+}
+
+func (id *Identity) setEnv(env *environments.Environment) {
+	id.env = env
+	id.initialized = true
+}
+
+func (id *Identity) PublicKeyEC() string {
+	if id.pubKeyEC == "" {
+		if id.secretKey != nil {
+			if pkEC, _ := id.secretKey.PublicKey(false); pkEC != nil {
+				id.pubKeyEC, _ = pkEC.String()
+			}
+		}
+	}
+	return id.pubKeyEC
+}
+
+func (id *Identity) PublicKeyPQ() string {
+	if id.pubKeyPQ == "" {
+		if id.secretKey != nil {
+			if pkPQ, _ := id.secretKey.PublicKey(true); pkPQ != nil {
+				id.pubKeyPQ, _ = pkPQ.String()
+			}
+		}
+	}
+	return id.pubKeyPQ
+}
+
+// HasPublicKey reports whether the given public key string belongs to the identity.
+func (id *Identity) HasPublicKey(publicKeyStr string) bool {
+	return publicKeyStr != "" && (publicKeyStr == id.PublicKeyEC() || publicKeyStr == id.PublicKeyPQ())
+}
+
+// Fingerprint returns the fingerprint of the public key of the identity.
+func (id *Identity) Fingerprint() string {
+	if pkEC, _ := id.secretKey.PublicKey(false); pkEC != nil {
+		return pkEC.Fingerprint()
+	}
+	return ""
+}
+
+// matches reports whether the identity is the one selected by the given environment name, public key or fingerprint.
+func (id *Identity) matches(query string) bool {
+	if id.HasPublicKey(query) {
+		return true
+	}
+	if fingerprint, err := crypto.ParseFingerprint(query); err == nil && fingerprint == id.Fingerprint() {
+		return true
+	}
+	env, _ := id.Env()
+	return env != nil && strings.EqualFold(env.Name, query)
+}
@@ -18,12 +18,13 @@ import (
 )
 
 const (
-	slvK8sConfigMap         = config.AppNameLowerCase
-	publicKeyNameEC         = "PublicKeyEC"
-	publicKeyNamePQ         = "PublicKeyPQ"
-	envar_NAMESPACE         = "NAMESPACE"
-	envar_SLV_K8S_NAMESPACE = "SLV_K8S_NAMESPACE"
-	namespaceFile           = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+	slvK8sConfigMap             = config.AppNameLowerCase
+	publicKeyNameEC             = "PublicKeyEC"
+	publicKeyNamePQ             = "PublicKeyPQ"
+	envar_NAMESPACE             = "NAMESPACE"
+	envar_SLV_K8S_NAMESPACE     = "SLV_K8S_NAMESPACE"
+	envar_SLV_K8S_VAULT_WRITERS = "SLV_K8S_VAULT_WRITERS"
+	namespaceFile               = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
 )
 
 var (
@@ -162,6 +163,24 @@ func GetK8sNamespace() string {
 	return *currentNamespace
 }
 
+// GetK8sVaultWriters returns the signing keys of the environments that are authorized to write to the vaults
+// in the cluster, as configured through SLV_K8S_VAULT_WRITERS. The writers they list in a vault are authorized as well.
+// Vault signatures are not enforced when it is unset.
+func GetK8sVaultWriters() ([]*crypto.VerificationKey, error) {
+	var writers []*crypto.VerificationKey
+	for _, writerStr := range strings.Split(os.Getenv(envar_SLV_K8S_VAULT_WRITERS), ",") {
+		if writerStr = strings.TrimSpace(writerStr); writerStr == "" {
+			continue
+		}
+		writer, err := crypto.VerificationKeyFromString(writerStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signing key in %s: %w", envar_SLV_K8S_VAULT_WRITERS, err)
+		}
+		writers = append(writers, writer)
+	}
+	return writers, nil
+}
+
 func isInKubernetesCluster() bool {
 	if _, err := os.Stat("/var/run/secrets/kubernetes.io/serviceaccount/token"); err == nil {
 		return true
@@ -1,25 +1,35 @@
 package session
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"unicode"
 
+	"slv.sh/slv/internal/core/agent"
 	"slv.sh/slv/internal/core/config"
 	"slv.sh/slv/internal/core/crypto"
 	"slv.sh/slv/internal/core/environments"
 	"slv.sh/slv/internal/core/environments/envproviders"
-	"slv.sh/slv/internal/core/profiles"
 )
 
 const (
-	envar_SLV_ENV_SECRET_KEY     = "SLV_ENV_SECRET_KEY"
-	envar_SLV_ENV_SECRET_BINDING = "SLV_ENV_SECRET_BINDING"
+	envar_SLV_ENV_SECRET_KEY          = "SLV_ENV_SECRET_KEY"
+	envar_SLV_ENV_SECRET_BINDING      = "SLV_ENV_SECRET_BINDING"
+	envar_SLV_ENV_SECRET_KEY_FILE     = "SLV_ENV_SECRET_KEY_FILE"
+	envar_SLV_ENV_SECRET_BINDING_FILE = "SLV_ENV_SECRET_BINDING_FILE"
 )
 
 var (
-	session          *Session
+	errSelfEnvSecretKeyMismatch = errors.New("the secret key found without a binding does not belong to the self environment")
+	errEnvAccessNotConfigured   = errors.New("environment access not configured")
+
+	session          atomic.Pointer[Session]
 	sessionInitMutex sync.Mutex
+	identityQuery    string
 	slvK8sSecret     = func() string {
 		if val := os.Getenv("SLV_K8S_ENV_SECRET"); val != "" {
 			return val
@@ -28,116 +38,243 @@ var (
 	}()
 )
 
+// Session holds the identities loaded from SLV_ENV_SECRET_KEY, SLV_ENV_SECRET_BINDING (both accept a comma
+// separated list, and may be read from the files given by SLV_ENV_SECRET_KEY_FILE and SLV_ENV_SECRET_BINDING_FILE),
+// the agent, the self environment and the k8s secret, in that order. The first identity is the primary one, which
+// acts wherever a single identity is needed, such as signing new vaults.
 type Session struct {
-	secretKey   *crypto.SecretKey
-	env         *environments.Environment
-	pubKeyEC    string
-	pubKeyPQ    string
-	initialized bool
-	initMutex   sync.Mutex
+	identities []*Identity
+}
+
+func (s *Session) primary() *Identity {
+	if len(s.identities) == 0 {
+		return nil
+	}
+	return s.identities[0]
 }
 
 func (s *Session) SecretKey() *crypto.SecretKey {
-	return s.secretKey
+	if primary := s.primary(); primary != nil {
+		return primary.SecretKey()
+	}
+	return nil
 }
 
-func (s *Session) Env() (*environments.Environment, error) {
-	if !s.initialized && s.secretKey != nil {
-		s.initMutex.Lock()
-		defer s.initMutex.Unlock()
-		if !s.initialized {
-			profile, err := profiles.GetActiveProfile()
-			if err != nil {
-				return nil, err
-			}
-			if s.env == nil {
-				if env, err := profile.GetEnv(s.pubKeyEC); err == nil {
-					s.env = env
-				}
-				if s.env == nil {
-					if env, err := profile.GetEnv(s.pubKeyPQ); err == nil {
-						s.env = env
-					}
-				}
-			}
-			s.initialized = true
+// SecretKeys returns the secret keys of all identities of the session, beginning with the primary one.
+func (s *Session) SecretKeys() []*crypto.SecretKey {
+	var secretKeys []*crypto.SecretKey
+	for _, id := range s.identities {
+		secretKeys = append(secretKeys, id.SecretKey())
+	}
+	return secretKeys
+}
+
+// Identities returns all identities of the session, beginning with the primary one.
+func (s *Session) Identities() []*Identity {
+	return s.identities
+}
+
+// Identity returns the identity of the session that holds the given public key, or nil if there is none.
+func (s *Session) Identity(publicKeyStr string) *Identity {
+	for _, id := range s.identities {
+		if id.HasPublicKey(publicKeyStr) {
+			return id
 		}
 	}
-	return s.env, nil
+	return nil
+}
+
+func (s *Session) Env() (*environments.Environment, error) {
+	if primary := s.primary(); primary != nil {
+		return primary.Env()
+	}
+	return nil, nil
 }
 
 func (s *Session) PublicKeyEC() string {
-	if s.pubKeyEC == "" {
-		if s.secretKey != nil {
-			if pkEC, _ := s.secretKey.PublicKey(false); pkEC != nil {
-				s.pubKeyEC, _ = pkEC.String()
-			}
-		}
+	if primary := s.primary(); primary != nil {
+		return primary.PublicKeyEC()
 	}
-	return s.pubKeyEC
+	return ""
 }
 
 func (s *Session) PublicKeyPQ() string {
-	if s.pubKeyPQ == "" {
-		if s.secretKey != nil {
-			if pkPQ, _ := s.secretKey.PublicKey(true); pkPQ != nil {
-				s.pubKeyPQ, _ = pkPQ.String()
-			}
+	if primary := s.primary(); primary != nil {
+		return primary.PublicKeyPQ()
+	}
+	return ""
+}
+
+// addIdentity adds the secret key as an identity unless the session already holds it, and returns the identity.
+func (s *Session) addIdentity(secretKey *crypto.SecretKey, source string) *Identity {
+	id := newIdentity(secretKey, source)
+	if existing := s.Identity(id.PublicKeyEC()); existing != nil {
+		return existing
+	}
+	s.identities = append(s.identities, id)
+	return id
+}
+
+// splitList splits a list of secret keys or secret bindings separated by commas or white space.
+func splitList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
+// getListFromEnv returns the list held by the environment variable, followed by that held by the file its _FILE
+// variant points to.
+func getListFromEnv(envar string) ([]string, error) {
+	values := splitList(os.Getenv(envar))
+	if path := os.Getenv(envar + "_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", envar+"_FILE", err)
 		}
+		values = append(values, splitList(string(data))...)
 	}
-	return s.pubKeyPQ
+	return values, nil
 }
 
-func GetSession() (*Session, error) {
-	if session == nil {
-		sessionInitMutex.Lock()
-		defer sessionInitMutex.Unlock()
-		if session == nil {
-			session = &Session{}
-			var err error
-			if secretKeyStr := os.Getenv(envar_SLV_ENV_SECRET_KEY); secretKeyStr != "" {
-				if session.secretKey, err = crypto.SecretKeyFromString(secretKeyStr); err != nil {
-					session = nil
-					return nil, err
-				}
-			}
-			if session.secretKey == nil {
-				envSecretBindingStr := os.Getenv(envar_SLV_ENV_SECRET_BINDING)
-				if envSecretBindingStr == "" {
-					if selfEnv := environments.GetSelf(); selfEnv != nil {
-						session.env = selfEnv
-						session.initialized = true
-						envSecretBindingStr = selfEnv.SecretBinding
-					}
-				}
-				if envSecretBindingStr != "" {
-					if session.secretKey, err = envproviders.GetSecretKeyFromSecretBinding(envSecretBindingStr); err != nil {
-						session = nil
-						return nil, err
-					}
-				}
-			}
-			if session.secretKey == nil && isInKubernetesCluster() {
-				if kubeClientSet, _ := getKubeClientSet(); kubeClientSet != nil {
-					session.secretKey, _ = getSecretKeyFor(kubeClientSet, GetK8sNamespace())
+// SetIdentity selects the identity of the session to act with by its environment name, public key or fingerprint.
+// It is to be called before the session is loaded.
+func SetIdentity(query string) {
+	identityQuery = strings.TrimSpace(query)
+}
+
+func (s *Session) selectIdentity(query string) error {
+	var selected []*Identity
+	for _, id := range s.identities {
+		if id.matches(query) {
+			selected = append(selected, id)
+		}
+	}
+	switch len(selected) {
+	case 0:
+		return fmt.Errorf("no identity of the session matches %s", query)
+	case 1:
+		s.identities = selected
+		return nil
+	default:
+		return fmt.Errorf("more than one identity of the session matches %s", query)
+	}
+}
+
+func loadSession() (*Session, error) {
+	s := &Session{}
+	secretKeyStrs, err := getListFromEnv(envar_SLV_ENV_SECRET_KEY)
+	if err != nil {
+		return nil, err
+	}
+	for _, secretKeyStr := range secretKeyStrs {
+		secretKey, err := crypto.SecretKeyFromString(secretKeyStr)
+		if err != nil {
+			return nil, err
+		}
+		s.addIdentity(secretKey, SourceSecretKey)
+	}
+	envSecretBindingStrs, err := getListFromEnv(envar_SLV_ENV_SECRET_BINDING)
+	if err != nil {
+		return nil, err
+	}
+	for _, envSecretBindingStr := range envSecretBindingStrs {
+		secretKey, err := envproviders.GetSecretKeyFromSecretBinding(envSecretBindingStr)
+		if err != nil {
+			return nil, err
+		}
+		s.addIdentity(secretKey, SourceSecretBinding)
+	}
+	selfEnv := environments.GetSelf()
+	// An unreachable agent is skipped, as the key may have expired or the agent may have been stopped.
+	if secretKey, _ := agent.GetSecretKey(); secretKey != nil {
+		if id := s.addIdentity(secretKey, SourceAgent); selfEnv != nil && id.HasPublicKey(selfEnv.PublicKey) {
+			id.setEnv(selfEnv)
+		}
+	}
+	// The self environment is only unlocked if no other source holds its secret key already. Failing to unlock
+	// it only fails the session if there is no other identity to act with.
+	if selfEnv != nil {
+		if id := s.Identity(selfEnv.PublicKey); id != nil {
+			id.setEnv(selfEnv)
+		} else if secretKey, err := envproviders.GetSecretKeyFromSecretBinding(selfEnv.SecretBinding); err == nil {
+			if selfEnv.SecretBinding == "" && !newIdentity(secretKey, SourceSelf).HasPublicKey(selfEnv.PublicKey) {
+				if len(s.identities) == 0 {
+					return nil, errSelfEnvSecretKeyMismatch
 				}
+			} else {
+				s.addIdentity(secretKey, SourceSelf).setEnv(selfEnv)
 			}
-			if isInKubernetesCluster() {
-				if kubeClientSet, _ := getKubeClientSet(); kubeClientSet != nil && (session.PublicKeyEC() != "" || session.PublicKeyPQ() != "") {
-					putPublicKeyToConfigMap(kubeClientSet, session.PublicKeyEC(), session.PublicKeyPQ())
-				}
+		} else if len(s.identities) == 0 {
+			return nil, err
+		}
+	}
+	if isInKubernetesCluster() {
+		if kubeClientSet, _ := getKubeClientSet(); kubeClientSet != nil {
+			if secretKey, _ := getSecretKeyFor(kubeClientSet, GetK8sNamespace()); secretKey != nil {
+				s.addIdentity(secretKey, SourceK8s)
 			}
 		}
 	}
-	return session, nil
+	if identityQuery != "" {
+		if err := s.selectIdentity(identityQuery); err != nil {
+			return nil, err
+		}
+	}
+	if isInKubernetesCluster() {
+		if kubeClientSet, _ := getKubeClientSet(); kubeClientSet != nil && (s.PublicKeyEC() != "" || s.PublicKeyPQ() != "") {
+			putPublicKeyToConfigMap(kubeClientSet, s.PublicKeyEC(), s.PublicKeyPQ())
+		}
+	}
+	return s, nil
+}
+
+func GetSession() (*Session, error) {
+	if s := session.Load(); s != nil {
+		return s, nil
+	}
+	sessionInitMutex.Lock()
+	defer sessionInitMutex.Unlock()
+	if s := session.Load(); s != nil {
+		return s, nil
+	}
+	s, err := loadSession()
+	if err != nil {
+		return nil, err
+	}
+	session.Store(s)
+	return s, nil
+}
+
+// Reload loads the session afresh, such as after the secret keys it was loaded with have been rotated. The current
+// session is kept if the new one fails to load.
+func Reload() error {
+	sessionInitMutex.Lock()
+	defer sessionInitMutex.Unlock()
+	s, err := loadSession()
+	if err != nil {
+		return err
+	}
+	session.Store(s)
+	return nil
 }
 
 func GetSecretKey() (*crypto.SecretKey, error) {
 	if session, err := GetSession(); err != nil {
 		return nil, err
 	} else if session.SecretKey() == nil {
-		return nil, fmt.Errorf("environment access not configured")
+		return nil, errEnvAccessNotConfigured
 	} else {
 		return session.SecretKey(), nil
 	}
 }
+
+// GetSecretKeys returns the secret keys of all identities of the session, beginning with the primary one.
+func GetSecretKeys() ([]*crypto.SecretKey, error) {
+	if session, err := GetSession(); err != nil {
+		return nil, err
+	} else if len(session.identities) == 0 {
+		return nil, errEnvAccessNotConfigured
+	} else {
+		return session.SecretKeys(), nil
+	}
+}
@@ -0,0 +1,112 @@
+package session
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const watchDebounce = 500 * time.Millisecond
+
+// Watcher reloads the session whenever the files given by SLV_ENV_SECRET_KEY_FILE or SLV_ENV_SECRET_BINDING_FILE
+// change, so that long running processes pick up rotated keys without restarting.
+type Watcher struct {
+	watcher  *fsnotify.Watcher
+	files    map[string][]byte
+	onReload func(err error)
+	done     chan struct{}
+}
+
+// WatchSecretFiles starts watching the secret key and secret binding files, and returns nil if neither is set.
+// onReload, if not nil, is called after every attempt to reload the session with the changed files. The current
+// session is kept if the reload fails.
+func WatchSecretFiles(onReload func(err error)) (*Watcher, error) {
+	w := &Watcher{files: make(map[string][]byte), onReload: onReload, done: make(chan struct{})}
+	for _, envar := range []string{envar_SLV_ENV_SECRET_KEY_FILE, envar_SLV_ENV_SECRET_BINDING_FILE} {
+		if path := os.Getenv(envar); path != "" {
+			w.files[filepath.Clean(path)], _ = os.ReadFile(path)
+		}
+	}
+	if len(w.files) == 0 {
+		return nil, nil
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	// The directories are watched rather than the files, as mounted secrets and editors replace files instead of
+	// writing to them.
+	for path := range w.files {
+		if err = watcher.Add(filepath.Dir(path)); err != nil {
+			watcher.Close()
+			return nil, err
+		}
+	}
+	w.watcher = watcher
+	go w.watch()
+	return w, nil
+}
+
+func (w *Watcher) isRelevant(event fsnotify.Event) bool {
+	path := filepath.Clean(event.Name)
+	if _, found := w.files[path]; found {
+		return true
+	}
+	// Kubernetes updates mounted secrets by swapping the ..data symlink the files point to.
+	return strings.HasPrefix(filepath.Base(path), "..")
+}
+
+func (w *Watcher) watch() {
+	var reload <-chan time.Time
+	for {
+		select {
+		case <-w.done:
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if w.isRelevant(event) {
+				reload = time.After(watchDebounce)
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			if w.onReload != nil {
+				w.onReload(err)
+			}
+		case <-reload:
+			reload = nil
+			if w.filesChanged() {
+				err := Reload()
+				if w.onReload != nil {
+					w.onReload(err)
+				}
+			}
+		}
+	}
+}
+
+// filesChanged reports whether the contents of any of the watched files differ from when they were last read.
+// Files that cannot be read, such as while they are being replaced, are left to the next change.
+func (w *Watcher) filesChanged() bool {
+	changed := false
+	for path, previous := range w.files {
+		if data, err := os.ReadFile(path); err == nil && !bytes.Equal(data, previous) {
+			w.files[path] = data
+			changed = true
+		}
+	}
+	return changed
+}
+
+// Close stops watching the files.
+func (w *Watcher) Close() error {
+	close(w.done)
+	return w.watcher.Close()
+}
@@ -7,4 +7,7 @@ import (
 var (
 	errManifestPathExistsAlready = errors.New("manifest path exists already")
 	errManifestNotFound          = errors.New("manifest not found")
+	errWritingManifest           = errors.New("error in writing manifest")
+	errUnknownSetting            = errors.New("unknown setting")
+	errInvalidSettingValue       = errors.New("invalid value")
 )
@@ -1,9 +1,22 @@
 package settings
 
 import (
+	"fmt"
+	"strconv"
+
 	"slv.sh/slv/internal/core/commons"
 )
 
+const (
+	AllowChangesKey      = "allowChanges"
+	AllowCreateEnvKey    = "allowCreateEnv"
+	AllowCreateGroupKey  = "allowCreateGroup"
+	SyncIntervalKey      = "syncInterval"
+	AllowGroupsKey       = "allowGroups"
+	AllowVaultSharingKey = "allowVaultSharing"
+	AuditRequiredKey     = "auditRequired"
+)
+
 type Settings struct {
 	path              *string
 	AllowChanges      bool `json:"allowChanges" yaml:"allowChanges"`
@@ -12,15 +25,26 @@ type Settings struct {
 	SyncInterval      int  `json:"syncInterval" yaml:"syncInterval"`
 	AllowGroups       bool `json:"allowGroups" yaml:"allowGroups"`
 	AllowVaultSharing bool `json:"allowVaultSharing" yaml:"allowVaultSharing"`
+	AuditRequired     bool `json:"auditRequired" yaml:"auditRequired"`
+}
+
+// newSettings returns the settings that apply when a profile leaves them out, which allow everything.
+func newSettings() *Settings {
+	return &Settings{
+		AllowChanges:      true,
+		AllowCreateEnv:    true,
+		AllowCreateGroup:  true,
+		AllowGroups:       true,
+		AllowVaultSharing: true,
+	}
 }
 
 func NewManifest(path string) (settings *Settings, err error) {
 	if commons.FileExists(path) {
 		return nil, errManifestPathExistsAlready
 	}
-	settings = &Settings{
-		path: &path,
-	}
+	settings = newSettings()
+	settings.path = &path
 	return
 }
 
@@ -28,10 +52,74 @@ func GetManifest(path string) (settings *Settings, err error) {
 	if !commons.FileExists(path) {
 		return nil, errManifestNotFound
 	}
-	settings = &Settings{}
+	settings = newSettings()
 	if err = commons.ReadFromYAML(path, settings); err != nil {
 		return nil, err
 	}
 	settings.path = &path
 	return
 }
+
+func (settings *Settings) write() error {
+	if commons.WriteToYAML(*settings.path, settings) != nil {
+		return errWritingManifest
+	}
+	return nil
+}
+
+// Keys returns the keys of the settings in the order they are listed in.
+func Keys() []string {
+	return []string{AllowChangesKey, AllowCreateEnvKey, AllowVaultSharingKey, AllowGroupsKey, AllowCreateGroupKey,
+		SyncIntervalKey, AuditRequiredKey}
+}
+
+func (settings *Settings) boolSetting(key string) *bool {
+	switch key {
+	case AllowChangesKey:
+		return &settings.AllowChanges
+	case AllowCreateEnvKey:
+		return &settings.AllowCreateEnv
+	case AllowCreateGroupKey:
+		return &settings.AllowCreateGroup
+	case AllowGroupsKey:
+		return &settings.AllowGroups
+	case AllowVaultSharingKey:
+		return &settings.AllowVaultSharing
+	case AuditRequiredKey:
+		return &settings.AuditRequired
+	}
+	return nil
+}
+
+// Get returns the value of the setting with the given key as a string.
+func (settings *Settings) Get(key string) (string, error) {
+	if key == SyncIntervalKey {
+		return strconv.Itoa(settings.SyncInterval), nil
+	}
+	if value := settings.boolSetting(key); value != nil {
+		return strconv.FormatBool(*value), nil
+	}
+	return "", fmt.Errorf("%w: %s", errUnknownSetting, key)
+}
+
+// Set parses the value for the setting with the given key and writes the settings.
+func (settings *Settings) Set(key, value string) error {
+	if key == SyncIntervalKey {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			return fmt.Errorf("%w for %s: %s", errInvalidSettingValue, key, value)
+		}
+		settings.SyncInterval = seconds
+		return settings.write()
+	}
+	setting := settings.boolSetting(key)
+	if setting == nil {
+		return fmt.Errorf("%w: %s", errUnknownSetting, key)
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("%w for %s: %s", errInvalidSettingValue, key, value)
+	}
+	*setting = enabled
+	return settings.write()
+}
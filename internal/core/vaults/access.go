@@ -18,19 +18,13 @@ func (vlt *Vault) share(publicKey *crypto.PublicKey, commit bool) (bool, error)
 	if publicKey.Type() == VaultKey {
 		return false, errVaultCannotBeSharedWithVault
 	}
-	for _, wrappedKeyStr := range vlt.Spec.Config.WrappedKeys {
-		wrappedKey := &crypto.WrappedKey{}
-		if err := wrappedKey.FromString(wrappedKeyStr); err != nil {
-			return false, err
-		}
-		if wrappedKey.IsEncryptedBy(publicKey) {
-			return false, nil
-		}
+	if shared, err := vlt.IsSharedWith(publicKey); err != nil || shared {
+		return false, err
 	}
 	wrappedKey, err := publicKey.EncryptKey(*vlt.Spec.secretKey)
 	if err == nil {
 		vlt.Spec.Config.WrappedKeys = append(vlt.Spec.Config.WrappedKeys, wrappedKey.String())
-		if commit {
+		if err = vlt.signKeys(); err == nil && commit {
 			err = vlt.commit()
 		}
 	}
@@ -50,6 +44,10 @@ func (vlt *Vault) Revoke(publicKeys []*crypto.PublicKey, quantumSafe bool) (err
 			return err
 		}
 	}
+	var fileKeys map[string][]byte
+	if fileKeys, err = vlt.getAllFileKeys(); err != nil {
+		return err
+	}
 	var accessors []crypto.PublicKey
 	if accessors, err = vlt.ListAccessors(); err != nil {
 		return err
@@ -102,11 +100,19 @@ func (vlt *Vault) Revoke(publicKeys []*crypto.PublicKey, quantumSafe bool) (err
 			return err
 		}
 	}
+	if err = vlt.signKeys(); err != nil {
+		return err
+	}
 	for name, vaultItem := range vaultItemsMap {
 		if err = vlt.putWithoutCommit(name, vaultItem.value, !vaultItem.IsPlaintext()); err != nil {
 			return err
 		}
 	}
+	for fileId, dataKey := range fileKeys {
+		if err = vlt.putFileKey(fileId, dataKey); err != nil {
+			return err
+		}
+	}
 	err = vlt.commit()
 	vlt.clearCache()
 	return
@@ -129,6 +135,19 @@ func (vlt *Vault) ListAccessors() ([]crypto.PublicKey, error) {
 	return accessors, nil
 }
 
+func (vlt *Vault) IsSharedWith(publicKey *crypto.PublicKey) (bool, error) {
+	for _, wrappedKeyStr := range vlt.Spec.Config.WrappedKeys {
+		wrappedKey := &crypto.WrappedKey{}
+		if err := wrappedKey.FromString(wrappedKeyStr); err != nil {
+			return false, err
+		}
+		if wrappedKey.IsEncryptedBy(publicKey) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (vlt *Vault) IsAccessibleBy(secretKey *crypto.SecretKey) bool {
 	pubKeyEC, err := secretKey.PublicKey(false)
 	if err != nil {
@@ -23,6 +23,22 @@ const (
 	k8sKind                 = config.K8SLVKind
 	k8sVaultSpecField       = config.K8SLVVaultField
 	k8sVersionAnnotationKey = config.K8SLVAnnotationVersionKey
+
+	keysSigningContext   = "slv-vault-keys"
+	itemSigningContext   = "slv-vault-item"
+	itemSealingContext   = "slv-sealed-item"
+	fileSigningContext   = "slv-vault-file"
+	fileSealingContext   = "slv-sealed-file"
+	writerSigningContext = "slv-vault-writer"
+
+	// vaultFormatVersion is recorded in vaults whose items are all sealed with the latest cryptography version,
+	// after which items sealed with an older version are rejected.
+	vaultFormatVersion uint8 = 2
+
+	fileMagic                = "SLVF"
+	fileFormatVersion   byte = 1
+	fileIdLength             = 16
+	fileSignaturePrefix      = "file:"
 )
 
 var (
@@ -45,4 +61,10 @@ var (
 	errK8sNameRequired              = errors.New("k8s resource name is required for a k8s compatible SLV vault")
 	errVaultWrappedKeysNotFound     = errors.New("vault wrapped keys not found - vault will be inaccessible by any environment")
 	errVaultNotWritable             = errors.New("vault is not writable")
+	errVaultSignatureRejected       = errors.New("vault is not signed by an authorized writer")
+	errVaultSignerNotSet            = errors.New("vault signer not set - unlock the vault with an environment that has access")
+	errVaultWriterNotFound          = errors.New("the signing key is not listed as a writer of the vault")
+	errVaultFileNotFound            = errors.New("the vault holds no data key for the file")
+	errInvalidFileFormat            = errors.New("invalid encrypted file format")
+	errUnsupportedFileVersion       = errors.New("unsupported encrypted file version")
 )
@@ -2,6 +2,7 @@ package vaults
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -18,7 +19,7 @@ func (vlt *Vault) putWithoutCommit(name string, value []byte, encrypt bool) (err
 		var vaultPublicKey *crypto.PublicKey
 		if vaultPublicKey, err = vlt.getPublicKey(); err == nil {
 			var sealedSecret *crypto.SealedSecret
-			if sealedSecret, err = vaultPublicKey.EncryptSecret(value, vlt.Spec.Config.Hash); err == nil {
+			if sealedSecret, err = vaultPublicKey.EncryptSecret(value, vlt.Spec.Config.Hash, vlt.itemAssociatedData(name)); err == nil {
 				finalValue = sealedSecret.String()
 			}
 		}
@@ -30,10 +31,17 @@ func (vlt *Vault) putWithoutCommit(name string, value []byte, encrypt bool) (err
 			vlt.Spec.Data = make(map[string]string)
 		}
 		vlt.Spec.Data[name] = finalValue
+		err = vlt.signItem(name)
 	}
 	return
 }
 
+// itemAssociatedData binds a sealed item to the vault and the name it is stored under, so that a sealed value
+// cannot be moved to another item or vault without failing decryption.
+func (vlt *Vault) itemAssociatedData(name string) []byte {
+	return []byte(strings.Join([]string{itemSealingContext, vlt.Spec.Config.PublicKey, name}, "\n"))
+}
+
 func (vlt *Vault) Put(name string, value []byte, encrypt bool) (err error) {
 	if !vlt.Spec.writable {
 		return errVaultNotWritable
@@ -124,6 +132,7 @@ func (vlt *Vault) Get(name string) (*VaultItem, error) {
 	if item == nil {
 		item = &VaultItem{
 			vlt:      vlt,
+			name:     name,
 			rawValue: rawValue,
 		}
 		sealedSecret := &crypto.SealedSecret{}
@@ -159,6 +168,7 @@ func (vlt *Vault) DeleteItems(names []string) error {
 	}
 	for _, name := range names {
 		delete(vlt.Spec.Data, name)
+		delete(vlt.Spec.Config.Signatures, name)
 		vlt.deleteFromCache(name)
 	}
 	return vlt.commit()
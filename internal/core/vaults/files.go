@@ -0,0 +1,171 @@
+package vaults
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"slices"
+	"strings"
+
+	"slv.sh/slv/internal/core/commons"
+	"slv.sh/slv/internal/core/crypto"
+)
+
+// An encrypted file starts with a header made of the file magic, the format version and the id of the file.
+// The data key of the file is sealed to the vault and recorded in it under the file id, so only environments
+// with access to the vault can decrypt the file. The header is authenticated along with every chunk of the file.
+
+func newFileId() ([]byte, error) {
+	fileId := make([]byte, fileIdLength)
+	if _, err := rand.Read(fileId); err != nil {
+		return nil, err
+	}
+	return fileId, nil
+}
+
+func fileHeader(fileId []byte) []byte {
+	return append(append([]byte(fileMagic), fileFormatVersion), fileId...)
+}
+
+func readFileHeader(src io.Reader) (header []byte, fileId string, err error) {
+	header = make([]byte, len(fileMagic)+1+fileIdLength)
+	if _, err = io.ReadFull(src, header); err != nil {
+		return nil, "", errInvalidFileFormat
+	}
+	if !bytes.HasPrefix(header, []byte(fileMagic)) {
+		return nil, "", errInvalidFileFormat
+	}
+	if header[len(fileMagic)] != fileFormatVersion {
+		return nil, "", errUnsupportedFileVersion
+	}
+	return header, commons.Encode(header[len(fileMagic)+1:]), nil
+}
+
+// fileAssociatedData binds a sealed data key to the vault and the id of the file it encrypts.
+func (vlt *Vault) fileAssociatedData(fileId string) []byte {
+	return []byte(strings.Join([]string{fileSealingContext, vlt.Spec.Config.PublicKey, fileId}, "\n"))
+}
+
+func (vlt *Vault) putFileKey(fileId string, dataKey []byte) error {
+	vaultPublicKey, err := vlt.getPublicKey()
+	if err != nil {
+		return err
+	}
+	sealedDataKey, err := vaultPublicKey.EncryptSecret(dataKey, false, vlt.fileAssociatedData(fileId))
+	if err != nil {
+		return err
+	}
+	if vlt.Spec.Config.Files == nil {
+		vlt.Spec.Config.Files = make(map[string]string)
+	}
+	vlt.Spec.Config.Files[fileId] = sealedDataKey.String()
+	return vlt.signFile(fileId)
+}
+
+func (vlt *Vault) getFileKey(fileId string) ([]byte, error) {
+	if vlt.IsLocked() {
+		return nil, errVaultLocked
+	}
+	sealedDataKeyStr, ok := vlt.Spec.Config.Files[fileId]
+	if !ok {
+		return nil, errVaultFileNotFound
+	}
+	sealedDataKey := &crypto.SealedSecret{}
+	if err := sealedDataKey.FromString(sealedDataKeyStr); err != nil {
+		return nil, err
+	}
+	return vlt.decryptSecret(*sealedDataKey, vlt.fileAssociatedData(fileId))
+}
+
+func (vlt *Vault) getAllFileKeys() (map[string][]byte, error) {
+	fileKeys := make(map[string][]byte)
+	for fileId := range vlt.Spec.Config.Files {
+		dataKey, err := vlt.getFileKey(fileId)
+		if err != nil {
+			return nil, err
+		}
+		fileKeys[fileId] = dataKey
+	}
+	return fileKeys, nil
+}
+
+// EncryptFile encrypts everything read from src into dst under a new data key. The data key is sealed to the vault
+// and recorded in it, so that the file can be decrypted by the environments that have access to the vault.
+// It returns the id of the file that the vault refers to it by.
+func (vlt *Vault) EncryptFile(dst io.Writer, src io.Reader) (string, error) {
+	if !vlt.Spec.writable {
+		return "", errVaultNotWritable
+	}
+	fileIdBytes, err := newFileId()
+	if err != nil {
+		return "", err
+	}
+	fileId := commons.Encode(fileIdBytes)
+	dataKey, err := crypto.NewDataKey()
+	if err != nil {
+		return "", err
+	}
+	header := fileHeader(fileIdBytes)
+	if _, err = dst.Write(header); err != nil {
+		return "", err
+	}
+	encrypter, err := crypto.NewStreamWriter(dst, dataKey, header)
+	if err != nil {
+		return "", err
+	}
+	if _, err = io.Copy(encrypter, src); err != nil {
+		return "", err
+	}
+	if err = encrypter.Close(); err != nil {
+		return "", err
+	}
+	if err = vlt.putFileKey(fileId, dataKey); err != nil {
+		return "", err
+	}
+	return fileId, vlt.commit()
+}
+
+// DecryptFile decrypts a file encrypted with EncryptFile from src into dst. The vault must be unlocked.
+// Data may already have been written to dst when an error is returned for a modified or truncated file.
+func (vlt *Vault) DecryptFile(dst io.Writer, src io.Reader) (string, error) {
+	if vlt.IsLocked() {
+		return "", errVaultLocked
+	}
+	header, fileId, err := readFileHeader(src)
+	if err != nil {
+		return "", err
+	}
+	dataKey, err := vlt.getFileKey(fileId)
+	if err != nil {
+		return fileId, err
+	}
+	decrypter, err := crypto.NewStreamReader(src, dataKey, header)
+	if err != nil {
+		return fileId, err
+	}
+	_, err = io.Copy(dst, decrypter)
+	return fileId, err
+}
+
+// ListFiles returns the ids of the encrypted files that the vault holds the data keys for.
+func (vlt *Vault) ListFiles() []string {
+	fileIds := make([]string, 0, len(vlt.Spec.Config.Files))
+	for fileId := range vlt.Spec.Config.Files {
+		fileIds = append(fileIds, fileId)
+	}
+	slices.Sort(fileIds)
+	return fileIds
+}
+
+// DeleteFile removes the data key of the file from the vault, after which the file can no longer be decrypted.
+func (vlt *Vault) DeleteFile(fileId string) error {
+	if !vlt.Spec.writable {
+		return errVaultNotWritable
+	}
+	if _, ok := vlt.Spec.Config.Files[fileId]; !ok {
+		return errVaultFileNotFound
+	}
+	delete(vlt.Spec.Config.Files, fileId)
+	delete(vlt.Spec.Config.Signatures, fileSignatureName(fileId))
+	return vlt.commit()
+}
@@ -7,6 +7,7 @@ import (
 )
 
 type VaultItem struct {
+	name        string     `json:"-"`
 	value       []byte     `json:"-"`
 	rawValue    string     `json:"-"`
 	plaintext   bool       `json:"-"`
@@ -27,7 +28,7 @@ func (vi *VaultItem) Value() (value []byte, err error) {
 			}
 			sealedSecret := &crypto.SealedSecret{}
 			if err = sealedSecret.FromString(vi.rawValue); err == nil {
-				vi.value, err = vi.vlt.Spec.secretKey.DecryptSecret(*sealedSecret)
+				vi.value, err = vi.vlt.decryptSecret(*sealedSecret, vi.vlt.itemAssociatedData(vi.name))
 			}
 			if err != nil {
 				return nil, err
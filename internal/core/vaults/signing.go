@@ -0,0 +1,304 @@
+package vaults
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"slv.sh/slv/internal/core/crypto"
+)
+
+type SignatureStatus string
+
+const (
+	SignatureValid        SignatureStatus = "valid"
+	SignatureMissing      SignatureStatus = "unsigned"
+	SignatureInvalid      SignatureStatus = "invalid"
+	SignatureUnauthorized SignatureStatus = "unauthorized"
+)
+
+// SignatureCheck is the outcome of checking a single signature in the vault.
+type SignatureCheck struct {
+	Status SignatureStatus         `json:"status"`
+	KeyId  string                  `json:"keyId,omitempty"`
+	Signer *crypto.VerificationKey `json:"-"`
+}
+
+// VerifyResult holds the signature checks of the wrapped keys, the items and the file keys of a vault.
+type VerifyResult struct {
+	Keys  SignatureCheck            `json:"keys"`
+	Items map[string]SignatureCheck `json:"items"`
+	Files map[string]SignatureCheck `json:"files,omitempty"`
+}
+
+// Verified reports whether the wrapped keys, every item and every file key are signed by an authorized writer.
+func (result *VerifyResult) Verified() bool {
+	if result.Keys.Status != SignatureValid {
+		return false
+	}
+	for _, check := range result.Items {
+		if check.Status != SignatureValid {
+			return false
+		}
+	}
+	for _, check := range result.Files {
+		if check.Status != SignatureValid {
+			return false
+		}
+	}
+	return true
+}
+
+// SetSigner sets the environment secret key used to sign the subsequent changes to the vault.
+// Unlocking the vault sets the unlocking environment as the signer.
+func (vlt *Vault) SetSigner(secretKey *crypto.SecretKey) error {
+	pqPublicKey, err := secretKey.PublicKey(true)
+	if err != nil {
+		return err
+	}
+	pq, err := vlt.IsSharedWith(pqPublicKey)
+	if err != nil {
+		return err
+	}
+	vlt.Spec.signer = secretKey
+	vlt.Spec.signerPQ = pq
+	return nil
+}
+
+// Sign signs the wrapped keys and all the items of the vault with the signer, vouching for the current contents.
+func (vlt *Vault) Sign() error {
+	if !vlt.Spec.writable {
+		return errVaultNotWritable
+	}
+	if vlt.Spec.signer == nil {
+		return errVaultSignerNotSet
+	}
+	if err := vlt.signKeys(); err != nil {
+		return err
+	}
+	for name := range vlt.Spec.Data {
+		if err := vlt.signItem(name); err != nil {
+			return err
+		}
+	}
+	for fileId := range vlt.Spec.Config.Files {
+		if err := vlt.signFile(fileId); err != nil {
+			return err
+		}
+	}
+	return vlt.commit()
+}
+
+// Verify checks the signatures of the wrapped keys, the items and the file keys of the vault.
+// A signature is accepted only if it is valid and made by one of the given writers.
+func (vlt *Vault) Verify(writers []*crypto.VerificationKey) *VerifyResult {
+	result := &VerifyResult{
+		Keys:  checkSignature(vlt.Spec.Config.KeysSignature, vlt.keysSigningData(), writers),
+		Items: make(map[string]SignatureCheck),
+	}
+	for name, rawValue := range vlt.Spec.Data {
+		result.Items[name] = checkSignature(vlt.Spec.Config.Signatures[name], vlt.itemSigningData(name, rawValue), writers)
+	}
+	if len(vlt.Spec.Config.Files) > 0 {
+		result.Files = make(map[string]SignatureCheck)
+		for fileId, sealedDataKey := range vlt.Spec.Config.Files {
+			result.Files[fileId] = checkSignature(vlt.Spec.Config.Signatures[fileSignatureName(fileId)],
+				vlt.fileSigningData(fileId, sealedDataKey), writers)
+		}
+	}
+	return result
+}
+
+// CheckSignatures returns an error unless the wrapped keys, every item and every file key are signed by one of the given writers.
+func (vlt *Vault) CheckSignatures(writers []*crypto.VerificationKey) error {
+	result := vlt.Verify(writers)
+	if result.Keys.Status != SignatureValid {
+		return fmt.Errorf("%w: wrapped keys are %s", errVaultSignatureRejected, result.Keys.Status)
+	}
+	for name, check := range result.Items {
+		if check.Status != SignatureValid {
+			return fmt.Errorf("%w: item %s is %s", errVaultSignatureRejected, name, check.Status)
+		}
+	}
+	for fileId, check := range result.Files {
+		if check.Status != SignatureValid {
+			return fmt.Errorf("%w: file key %s is %s", errVaultSignatureRejected, fileId, check.Status)
+		}
+	}
+	return nil
+}
+
+func checkSignature(signatureStr string, data []byte, writers []*crypto.VerificationKey) SignatureCheck {
+	if signatureStr == "" {
+		return SignatureCheck{Status: SignatureMissing}
+	}
+	signature, err := crypto.SignatureFromString(signatureStr)
+	if err != nil {
+		return SignatureCheck{Status: SignatureInvalid}
+	}
+	for _, writer := range writers {
+		if signature.IsSignedBy(writer) {
+			if writer.Verify(data, signature) {
+				return SignatureCheck{Status: SignatureValid, KeyId: signature.KeyId(), Signer: writer}
+			}
+			return SignatureCheck{Status: SignatureInvalid, KeyId: signature.KeyId(), Signer: writer}
+		}
+	}
+	return SignatureCheck{Status: SignatureUnauthorized, KeyId: signature.KeyId()}
+}
+
+func (vlt *Vault) keysSigningData() []byte {
+	wrappedKeys := slices.Clone(vlt.Spec.Config.WrappedKeys)
+	slices.Sort(wrappedKeys)
+	fields := []string{keysSigningContext, vlt.Spec.Config.PublicKey, strconv.FormatBool(vlt.Spec.Config.Hash)}
+	// The format version is covered once recorded, so that it cannot be removed to have older items accepted again.
+	if vlt.Spec.Config.Version > 0 {
+		fields = append(fields, "v"+strconv.Itoa(int(vlt.Spec.Config.Version)))
+	}
+	return []byte(strings.Join(append(fields, wrappedKeys...), "\n"))
+}
+
+func (vlt *Vault) itemSigningData(name, rawValue string) []byte {
+	return []byte(strings.Join([]string{itemSigningContext, vlt.Spec.Config.PublicKey, name, rawValue}, "\n"))
+}
+
+// fileSignatureName is the entry under which the signature of a file key is stored. It cannot clash with
+// item names, which may only contain word characters.
+func fileSignatureName(fileId string) string {
+	return fileSignaturePrefix + fileId
+}
+
+func (vlt *Vault) fileSigningData(fileId, sealedDataKey string) []byte {
+	return []byte(strings.Join([]string{fileSigningContext, vlt.Spec.Config.PublicKey, fileId, sealedDataKey}, "\n"))
+}
+
+// writerSigningData names the vault a writer is authorized for by its name and namespace rather than its public key,
+// which changes whenever access is revoked.
+func (vlt *Vault) writerSigningData(writer string) []byte {
+	return []byte(strings.Join([]string{writerSigningContext, vlt.Namespace, vlt.Name, writer}, "\n"))
+}
+
+// Writers returns the signing keys authorized to write to the vault: the given trusted signers, along with the
+// writers listed in the vault that one of them authorized, directly or through other listed writers.
+func (vlt *Vault) Writers(trustedSigners []*crypto.VerificationKey) []*crypto.VerificationKey {
+	writers := slices.Clone(trustedSigners)
+	pending := make(map[string]string)
+	for writerStr, signatureStr := range vlt.Spec.Config.Writers {
+		pending[writerStr] = signatureStr
+	}
+	for authorized := true; authorized; {
+		authorized = false
+		for writerStr, signatureStr := range pending {
+			writer, err := crypto.VerificationKeyFromString(writerStr)
+			if err != nil {
+				delete(pending, writerStr)
+				continue
+			}
+			if slices.ContainsFunc(writers, writer.Equals) {
+				delete(pending, writerStr)
+				continue
+			}
+			if check := checkSignature(signatureStr, vlt.writerSigningData(writerStr), writers); check.Status == SignatureValid {
+				writers = append(writers, writer)
+				delete(pending, writerStr)
+				authorized = true
+			}
+		}
+	}
+	return writers
+}
+
+// AddWriter lists the signing key as a writer of the vault, authorized by the given secret key on behalf of its
+// signing key of the given kind. The authorization only counts if that signing key is itself an authorized writer.
+func (vlt *Vault) AddWriter(writer *crypto.VerificationKey, secretKey *crypto.SecretKey, postQuantum bool) error {
+	if !vlt.Spec.writable {
+		return errVaultNotWritable
+	}
+	writerStr := writer.String()
+	signature, err := secretKey.Sign(vlt.writerSigningData(writerStr), postQuantum)
+	if err != nil {
+		return err
+	}
+	if vlt.Spec.Config.Writers == nil {
+		vlt.Spec.Config.Writers = make(map[string]string)
+	}
+	vlt.Spec.Config.Writers[writerStr] = signature.String()
+	return vlt.commit()
+}
+
+// RemoveWriter withdraws the signing key from the writers of the vault. The writers it authorized are no longer
+// authorized either, unless another writer authorized them.
+func (vlt *Vault) RemoveWriter(writer *crypto.VerificationKey) error {
+	if !vlt.Spec.writable {
+		return errVaultNotWritable
+	}
+	writerStr := writer.String()
+	if _, found := vlt.Spec.Config.Writers[writerStr]; !found {
+		return errVaultWriterNotFound
+	}
+	delete(vlt.Spec.Config.Writers, writerStr)
+	return vlt.commit()
+}
+
+// ListWriters returns the signing keys listed as writers of the vault, whether authorized or not.
+func (vlt *Vault) ListWriters() []*crypto.VerificationKey {
+	var writers []*crypto.VerificationKey
+	for writerStr := range vlt.Spec.Config.Writers {
+		if writer, err := crypto.VerificationKeyFromString(writerStr); err == nil {
+			writers = append(writers, writer)
+		}
+	}
+	return writers
+}
+
+func (vlt *Vault) sign(data []byte) (string, error) {
+	if vlt.Spec.signer == nil {
+		return "", nil
+	}
+	signature, err := vlt.Spec.signer.Sign(data, vlt.Spec.signerPQ)
+	if err != nil {
+		return "", err
+	}
+	return signature.String(), nil
+}
+
+func (vlt *Vault) signKeys() (err error) {
+	vlt.Spec.Config.KeysSignature, err = vlt.sign(vlt.keysSigningData())
+	return
+}
+
+func (vlt *Vault) signItem(name string) error {
+	var signature string
+	if rawValue, ok := vlt.Spec.Data[name]; ok {
+		var err error
+		if signature, err = vlt.sign(vlt.itemSigningData(name, rawValue)); err != nil {
+			return err
+		}
+	}
+	if signature == "" {
+		delete(vlt.Spec.Config.Signatures, name)
+		return nil
+	}
+	if vlt.Spec.Config.Signatures == nil {
+		vlt.Spec.Config.Signatures = make(map[string]string)
+	}
+	vlt.Spec.Config.Signatures[name] = signature
+	return nil
+}
+
+func (vlt *Vault) signFile(fileId string) error {
+	signature, err := vlt.sign(vlt.fileSigningData(fileId, vlt.Spec.Config.Files[fileId]))
+	if err != nil {
+		return err
+	}
+	if signature == "" {
+		delete(vlt.Spec.Config.Signatures, fileSignatureName(fileId))
+		return nil
+	}
+	if vlt.Spec.Config.Signatures == nil {
+		vlt.Spec.Config.Signatures = make(map[string]string)
+	}
+	vlt.Spec.Config.Signatures[fileSignatureName(fileId)] = signature
+	return nil
+}
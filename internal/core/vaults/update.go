@@ -101,11 +101,17 @@ func (v *VaultSpec) DeepCopyInto(out *VaultSpec) {
 	if v.secretKey != nil {
 		out.secretKey = v.secretKey
 	}
+	out.signer = v.signer
+	out.signerPQ = v.signerPQ
 	out.publicKey = v.publicKey
 	out.vaultSecretRefRegex = v.vaultSecretRefRegex
 	out.Config = vaultConfig{
-		PublicKey:   v.Config.PublicKey,
-		Hash:        v.Config.Hash,
-		WrappedKeys: v.Config.WrappedKeys,
+		PublicKey:     v.Config.PublicKey,
+		Hash:          v.Config.Hash,
+		WrappedKeys:   v.Config.WrappedKeys,
+		KeysSignature: v.Config.KeysSignature,
+		Signatures:    maps.Clone(v.Config.Signatures),
+		Files:         maps.Clone(v.Config.Files),
+		Version:       v.Config.Version,
 	}
 }
@@ -0,0 +1,93 @@
+package vaults
+
+import (
+	"slv.sh/slv/internal/core/crypto"
+)
+
+// decryptSecret decrypts a sealed item or file key of the vault. Once the format version is recorded, secrets
+// sealed with an older cryptography version are rejected, as they are not bound to the vault and their names.
+func (vlt *Vault) decryptSecret(sealedSecret crypto.SealedSecret, associatedData []byte) ([]byte, error) {
+	if vlt.Spec.Config.Version >= vaultFormatVersion {
+		return vlt.Spec.secretKey.DecryptBoundSecret(sealedSecret, associatedData)
+	}
+	return vlt.Spec.secretKey.DecryptSecret(sealedSecret, associatedData)
+}
+
+// NeedsUpgrade reports whether any wrapped key or sealed item of the vault was encrypted with an older
+// cryptography version, or whether the vault does not record the latest format version yet.
+func (vlt *Vault) NeedsUpgrade() bool {
+	if vlt.Spec.Config.Version < vaultFormatVersion {
+		return true
+	}
+	for _, wrappedKeyStr := range vlt.Spec.Config.WrappedKeys {
+		wrappedKey := &crypto.WrappedKey{}
+		if err := wrappedKey.FromString(wrappedKeyStr); err == nil && wrappedKey.IsOutdated() {
+			return true
+		}
+	}
+	for _, rawValue := range vlt.Spec.Data {
+		sealedSecret := &crypto.SealedSecret{}
+		if err := sealedSecret.FromString(rawValue); err == nil && sealedSecret.IsOutdated() {
+			return true
+		}
+	}
+	return false
+}
+
+// Upgrade re-wraps the keys and re-seals the items of the vault that were encrypted with an older cryptography
+// version, binding every sealed item to the vault and its name. The format version is then recorded, after which
+// items sealed with an older version are rejected. It returns the number of upgraded entries.
+func (vlt *Vault) Upgrade() (upgraded int, err error) {
+	if !vlt.Spec.writable {
+		return 0, errVaultNotWritable
+	}
+	if vlt.IsLocked() {
+		return 0, errVaultLocked
+	}
+	wrappedKeys := make([]string, 0, len(vlt.Spec.Config.WrappedKeys))
+	for _, wrappedKeyStr := range vlt.Spec.Config.WrappedKeys {
+		wrappedKey := &crypto.WrappedKey{}
+		if err = wrappedKey.FromString(wrappedKeyStr); err != nil {
+			return 0, err
+		}
+		if wrappedKey.IsOutdated() {
+			accessor, err := wrappedKey.EncryptedByPublicKey()
+			if err != nil {
+				return 0, err
+			}
+			if wrappedKey, err = accessor.EncryptKey(*vlt.Spec.secretKey); err != nil {
+				return 0, err
+			}
+			upgraded++
+		}
+		wrappedKeys = append(wrappedKeys, wrappedKey.String())
+	}
+	versionOutdated := vlt.Spec.Config.Version < vaultFormatVersion
+	if upgraded > 0 || versionOutdated {
+		vlt.Spec.Config.WrappedKeys = wrappedKeys
+		vlt.Spec.Config.Version = vaultFormatVersion
+		if err = vlt.signKeys(); err != nil {
+			return 0, err
+		}
+	}
+	for name, rawValue := range vlt.Spec.Data {
+		sealedSecret := &crypto.SealedSecret{}
+		if sealedSecret.FromString(rawValue) != nil || !sealedSecret.IsOutdated() {
+			continue
+		}
+		value, err := vlt.Spec.secretKey.DecryptSecret(*sealedSecret, nil)
+		if err != nil {
+			return 0, err
+		}
+		if err = vlt.putWithoutCommit(name, value, true); err != nil {
+			return 0, err
+		}
+		upgraded++
+	}
+	if upgraded == 0 && !versionOutdated {
+		return 0, nil
+	}
+	err = vlt.commit()
+	vlt.clearCache()
+	return
+}
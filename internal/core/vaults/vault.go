@@ -18,9 +18,14 @@ import (
 )
 
 type vaultConfig struct {
-	PublicKey   string   `json:"publicKey" yaml:"publicKey"`
-	Hash        bool     `json:"hash,omitempty" yaml:"hash,omitempty"`
-	WrappedKeys []string `json:"wrappedKeys" yaml:"wrappedKeys"`
+	PublicKey     string            `json:"publicKey" yaml:"publicKey"`
+	Hash          bool              `json:"hash,omitempty" yaml:"hash,omitempty"`
+	WrappedKeys   []string          `json:"wrappedKeys" yaml:"wrappedKeys"`
+	KeysSignature string            `json:"keysSignature,omitempty" yaml:"keysSignature,omitempty"`
+	Signatures    map[string]string `json:"signatures,omitempty" yaml:"signatures,omitempty"`
+	Writers       map[string]string `json:"writers,omitempty" yaml:"writers,omitempty"`
+	Files         map[string]string `json:"files,omitempty" yaml:"files,omitempty"`
+	Version       uint8             `json:"version,omitempty" yaml:"version,omitempty"`
 }
 
 type Vault struct {
@@ -38,6 +43,8 @@ type VaultSpec struct {
 	path                string                `json:"-" yaml:"-"`
 	publicKey           *crypto.PublicKey     `json:"-" yaml:"-"`
 	secretKey           *crypto.SecretKey     `json:"-" yaml:"-"`
+	signer              *crypto.SecretKey     `json:"-" yaml:"-"`
+	signerPQ            bool                  `json:"-" yaml:"-"`
 	cache               map[string]*VaultItem `json:"-" yaml:"-"`
 	vaultSecretRefRegex *regexp.Regexp        `json:"-" yaml:"-"`
 }
@@ -102,6 +109,7 @@ func New(vaultFile, name, k8sNamespace string, hash, quantumSafe bool, publicKey
 			Config: vaultConfig{
 				PublicKey: vaultPubKeyStr,
 				Hash:      hash,
+				Version:   vaultFormatVersion,
 			},
 			path:      vaultFile,
 			secretKey: vaultSecretKey,
@@ -171,6 +179,7 @@ func (vlt *Vault) IsLocked() bool {
 func (vlt *Vault) Lock() {
 	vlt.clearCache()
 	vlt.Spec.secretKey = nil
+	vlt.Spec.signer = nil
 }
 
 func (vlt *Vault) Delete() error {
@@ -229,19 +238,30 @@ func (vlt *Vault) validateAndUpdate() error {
 	return nil
 }
 
-func (vlt *Vault) Unlock(secretKey *crypto.SecretKey) error {
+// Unlock unlocks the vault with the first of the given secret keys that has access to it, which also becomes
+// the signer of the subsequent changes.
+func (vlt *Vault) Unlock(secretKeys ...*crypto.SecretKey) error {
 	if !vlt.IsLocked() {
 		return nil
 	}
+	var wrappedKeys []*crypto.WrappedKey
 	for _, wrappedKeyStr := range vlt.Spec.Config.WrappedKeys {
 		wrappedKey := &crypto.WrappedKey{}
 		if err := wrappedKey.FromString(wrappedKeyStr); err != nil {
 			return err
 		}
-		decryptedKey, err := secretKey.DecryptKey(*wrappedKey)
-		if err == nil {
-			vlt.Spec.secretKey = decryptedKey
-			return nil
+		wrappedKeys = append(wrappedKeys, wrappedKey)
+	}
+	for _, secretKey := range secretKeys {
+		if secretKey == nil {
+			continue
+		}
+		for _, wrappedKey := range wrappedKeys {
+			decryptedKey, err := secretKey.DecryptKey(*wrappedKey)
+			if err == nil {
+				vlt.Spec.secretKey = decryptedKey
+				return vlt.SetSigner(secretKey)
+			}
 		}
 	}
 	return errVaultNotAccessible
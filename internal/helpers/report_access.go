@@ -0,0 +1,174 @@
+package helpers
+
+import (
+	"path/filepath"
+	"slices"
+	"time"
+
+	"slv.sh/slv/internal/core/environments"
+	"slv.sh/slv/internal/core/profiles"
+	"slv.sh/slv/internal/core/vaults"
+)
+
+// AccessReport is a matrix of vaults and the environments that can access them.
+type AccessReport struct {
+	GeneratedAt time.Time            `json:"generatedAt"`
+	Profile     string               `json:"profile,omitempty"`
+	Vaults      []*AccessReportVault `json:"vaults"`
+	Envs        []*AccessReportEnv   `json:"envs"`
+}
+
+type AccessReportVault struct {
+	File            string     `json:"file"`
+	Name            string     `json:"name,omitempty"`
+	PublicKey       string     `json:"publicKey"`
+	Items           int        `json:"items"`
+	LastEncryptedAt *time.Time `json:"lastEncryptedAt,omitempty"`
+	Accessors       []string   `json:"accessors"`
+	SingleAccessor  bool       `json:"singleAccessor,omitempty"`
+	Error           string     `json:"error,omitempty"`
+}
+
+type AccessReportEnv struct {
+	PublicKey string   `json:"publicKey"`
+	Name      string   `json:"name,omitempty"`
+	Type      string   `json:"type,omitempty"`
+	Email     string   `json:"email,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Profile   string   `json:"profile,omitempty"`
+	Root      bool     `json:"root,omitempty"`
+	Orphaned  bool     `json:"orphaned,omitempty"`
+	Vaults    int      `json:"vaults"`
+}
+
+// HasAccess reports whether the environment with the given public key is an accessor of the vault.
+func (v *AccessReportVault) HasAccess(publicKey string) bool {
+	return slices.Contains(v.Accessors, publicKey)
+}
+
+// GetAccessReport builds an access report for the vaults found in the given directories.
+// Accessors are resolved against the active profile first and then against all other profiles;
+// accessors that are not found in any profile are marked as orphaned.
+func GetAccessReport(dirs []string, recursive bool) (*AccessReport, error) {
+	report := &AccessReport{GeneratedAt: time.Now()}
+	envIndex := make(map[string]*AccessReportEnv)
+	lookupProfiles := getReportProfiles()
+	if len(lookupProfiles) > 0 {
+		activeProfile := lookupProfiles[0]
+		report.Profile = activeProfile.Name()
+		if envs, err := activeProfile.ListEnvs(); err == nil {
+			for _, env := range envs {
+				envIndex[env.PublicKey] = newAccessReportEnv(env, activeProfile.Name(), false)
+				report.Envs = append(report.Envs, envIndex[env.PublicKey])
+			}
+		}
+	}
+	if len(dirs) == 0 {
+		dirs = []string{""}
+	}
+	for _, dir := range dirs {
+		vaultFiles, err := ListVaultFiles(dir, recursive)
+		if err != nil {
+			return nil, err
+		}
+		for _, vaultFile := range vaultFiles {
+			vaultFile = filepath.Join(dir, vaultFile)
+			reportVault := &AccessReportVault{File: vaultFile}
+			report.Vaults = append(report.Vaults, reportVault)
+			vault, err := vaults.Get(vaultFile)
+			if err != nil {
+				reportVault.Error = err.Error()
+				continue
+			}
+			reportVault.Name = vault.Name
+			reportVault.PublicKey = vault.Spec.Config.PublicKey
+			items, err := vault.GetAllItems()
+			if err != nil {
+				reportVault.Error = err.Error()
+				continue
+			}
+			reportVault.Items = len(items)
+			for _, item := range items {
+				if encryptedAt := item.EncryptedAt(); encryptedAt != nil &&
+					(reportVault.LastEncryptedAt == nil || encryptedAt.After(*reportVault.LastEncryptedAt)) {
+					reportVault.LastEncryptedAt = encryptedAt
+				}
+			}
+			accessors, err := vault.ListAccessors()
+			if err != nil {
+				reportVault.Error = err.Error()
+				continue
+			}
+			for _, accessor := range accessors {
+				accessorPubKey, err := accessor.String()
+				if err != nil {
+					reportVault.Error = err.Error()
+					break
+				}
+				reportEnv := envIndex[accessorPubKey]
+				if reportEnv == nil {
+					reportEnv = resolveAccessReportEnv(accessorPubKey, lookupProfiles)
+					envIndex[accessorPubKey] = reportEnv
+					report.Envs = append(report.Envs, reportEnv)
+				}
+				reportEnv.Vaults++
+				reportVault.Accessors = append(reportVault.Accessors, accessorPubKey)
+			}
+			reportVault.SingleAccessor = len(reportVault.Accessors) == 1
+		}
+	}
+	return report, nil
+}
+
+// getReportProfiles returns all profiles with the active profile (if any) as the first element.
+func getReportProfiles() (reportProfiles []*profiles.Profile) {
+	activeProfileName, _ := profiles.GetActiveProfileName()
+	if activeProfile, err := profiles.GetActiveProfile(); err == nil {
+		reportProfiles = append(reportProfiles, activeProfile)
+	}
+	profileNames, _ := profiles.List()
+	slices.Sort(profileNames)
+	for _, profileName := range profileNames {
+		if profileName == activeProfileName {
+			continue
+		}
+		if profile, err := profiles.Get(profileName); err == nil {
+			reportProfiles = append(reportProfiles, profile)
+		}
+	}
+	return
+}
+
+func resolveAccessReportEnv(publicKey string, lookupProfiles []*profiles.Profile) *AccessReportEnv {
+	for _, profile := range lookupProfiles {
+		if root, _ := profile.GetRoot(); root != nil && root.PublicKey == publicKey {
+			return newAccessReportEnv(root, profile.Name(), true)
+		}
+		if env, _ := profile.GetEnv(publicKey); env != nil {
+			return newAccessReportEnv(env, profile.Name(), false)
+		}
+	}
+	reportEnv := &AccessReportEnv{
+		PublicKey: publicKey,
+		Orphaned:  true,
+	}
+	if self := environments.GetSelf(); self != nil && self.PublicKey == publicKey {
+		reportEnv.Name = self.Name
+		reportEnv.Type = string(self.EnvType)
+		reportEnv.Email = self.Email
+		reportEnv.Tags = self.Tags
+	}
+	return reportEnv
+}
+
+func newAccessReportEnv(env *environments.Environment, profileName string, root bool) *AccessReportEnv {
+	return &AccessReportEnv{
+		PublicKey: env.PublicKey,
+		Name:      env.Name,
+		Type:      string(env.EnvType),
+		Email:     env.Email,
+		Tags:      env.Tags,
+		Profile:   profileName,
+		Root:      root,
+	}
+}
@@ -0,0 +1,189 @@
+package helpers
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+
+	"slv.sh/slv/internal/core/crypto"
+	"slv.sh/slv/internal/core/environments"
+	"slv.sh/slv/internal/core/profiles"
+	"slv.sh/slv/internal/core/session"
+	"slv.sh/slv/internal/core/vaults"
+)
+
+var errVaultNotAccessible = errors.New("vault is not accessible using the current session")
+
+// VaultAccessResult holds the outcome of a bulk access change for a single vault.
+type VaultAccessResult struct {
+	VaultFile string `json:"vaultFile"`
+	Changed   bool   `json:"changed,omitempty"`
+	Skipped   bool   `json:"skipped,omitempty"`
+	Err       error  `json:"-"`
+}
+
+func (r *VaultAccessResult) Failed() bool {
+	return r.Err != nil && !r.Skipped
+}
+
+// CheckVaultSharing returns an error if the settings of the active profile do not allow sharing vaults with the
+// given public keys. Vaults can always be shared with the environments of the current session, the self
+// environment and the root of the profile.
+func CheckVaultSharing(publicKeys []*crypto.PublicKey) error {
+	profile, err := profiles.GetActiveProfile()
+	if err != nil {
+		return nil
+	}
+	sharingErr := profile.CheckVaultSharing()
+	if sharingErr == nil {
+		return nil
+	}
+	var sess *session.Session
+	if s, err := session.GetSession(); err == nil {
+		sess = s
+	}
+	root, _ := profile.GetRoot()
+	self := environments.GetSelf()
+	for _, publicKey := range publicKeys {
+		publicKeyStr, err := publicKey.String()
+		if err != nil {
+			return err
+		}
+		if sessionIdentity(sess, publicKeyStr) == nil && (root == nil || root.PublicKey != publicKeyStr) &&
+			(self == nil || self.PublicKey != publicKeyStr) {
+			return sharingErr
+		}
+	}
+	return nil
+}
+
+// GrantVaultAccess shares each of the given vault files with the given public keys.
+// Vaults that cannot be unlocked with any of the secret keys are skipped and processing continues with the next vault.
+// With dryRun set, vaults are only inspected and reported as changed if at least one public key would be added.
+func GrantVaultAccess(baseDir string, vaultFiles []string, secretKeys []*crypto.SecretKey,
+	publicKeys []*crypto.PublicKey, dryRun bool) (results []*VaultAccessResult) {
+	for _, vaultFile := range vaultFiles {
+		result := &VaultAccessResult{VaultFile: vaultFile}
+		results = append(results, result)
+		vault, err := openVaultForAccessChange(filepath.Join(baseDir, vaultFile), secretKeys, result)
+		if err != nil {
+			result.Err = err
+			continue
+		}
+		for _, publicKey := range publicKeys {
+			var shared bool
+			if dryRun {
+				if shared, err = vault.IsSharedWith(publicKey); err == nil {
+					shared = !shared
+				}
+			} else {
+				shared, err = vault.Share(publicKey)
+			}
+			if err != nil {
+				result.Err = err
+				break
+			}
+			result.Changed = result.Changed || shared
+		}
+	}
+	return results
+}
+
+// RevokeVaultAccess revokes access to each of the given vault files for the given public keys using Vault.Revoke.
+// Vaults that cannot be unlocked with any of the secret keys are skipped and processing continues with the next vault.
+// With dryRun set, vaults are only inspected and reported as changed if at least one public key has access.
+func RevokeVaultAccess(baseDir string, vaultFiles []string, secretKeys []*crypto.SecretKey,
+	publicKeys []*crypto.PublicKey, quantumSafe, dryRun bool) (results []*VaultAccessResult) {
+	for _, vaultFile := range vaultFiles {
+		result := &VaultAccessResult{VaultFile: vaultFile}
+		results = append(results, result)
+		vault, err := openVaultForAccessChange(filepath.Join(baseDir, vaultFile), secretKeys, result)
+		if err != nil {
+			result.Err = err
+			continue
+		}
+		for _, publicKey := range publicKeys {
+			var shared bool
+			if shared, err = vault.IsSharedWith(publicKey); err != nil {
+				break
+			}
+			result.Changed = result.Changed || shared
+		}
+		if err == nil && result.Changed && !dryRun {
+			err = vault.Revoke(publicKeys, quantumSafe)
+		}
+		result.Err = err
+	}
+	return results
+}
+
+// ReplaceVaultAccess shares the vault file with the new public key and then revokes the old public keys using
+// Vault.Revoke, so that the vault key is rotated as well. Vaults that cannot be unlocked with the secret key are skipped.
+func ReplaceVaultAccess(vaultFile string, secretKey *crypto.SecretKey, oldPublicKeys []*crypto.PublicKey,
+	newPublicKey *crypto.PublicKey, quantumSafe bool) *VaultAccessResult {
+	result := &VaultAccessResult{VaultFile: vaultFile}
+	vault, err := openVaultForAccessChange(vaultFile, []*crypto.SecretKey{secretKey}, result)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	if result.Changed, err = vault.Share(newPublicKey); err == nil {
+		if err = vault.Revoke(oldPublicKeys, quantumSafe); err == nil {
+			result.Changed = true
+		}
+	}
+	result.Err = err
+	return result
+}
+
+// openVaultForAccessChange returns the vault unlocked with the first of the secret keys that has access to it, such
+// as the keys of the identities the session can act as.
+func openVaultForAccessChange(vaultFile string, secretKeys []*crypto.SecretKey, result *VaultAccessResult) (*vaults.Vault, error) {
+	vault, err := vaults.Get(vaultFile)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(secretKeys, func(secretKey *crypto.SecretKey) bool {
+		return secretKey != nil && vault.IsAccessibleBy(secretKey)
+	}) {
+		result.Skipped = true
+		return nil, errVaultNotAccessible
+	}
+	if err = vault.Unlock(secretKeys...); err != nil {
+		return nil, err
+	}
+	return vault, nil
+}
+
+// FindVaultsSharedWith recursively scans the given directories and returns the paths of the vaults
+// that are shared with at least one of the given public keys. Files that fail to load as vaults are ignored.
+func FindVaultsSharedWith(dirs []string, publicKeys []*crypto.PublicKey) ([]string, error) {
+	var sharedVaultFiles []string
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		vaultFiles, err := ListVaultFiles(dir, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, vaultFile := range vaultFiles {
+			vaultFile = filepath.Join(dir, vaultFile)
+			if absPath, err := filepath.Abs(vaultFile); err == nil {
+				if seen[absPath] {
+					continue
+				}
+				seen[absPath] = true
+			}
+			vault, err := vaults.Get(vaultFile)
+			if err != nil {
+				continue
+			}
+			for _, publicKey := range publicKeys {
+				if shared, err := vault.IsSharedWith(publicKey); err == nil && shared {
+					sharedVaultFiles = append(sharedVaultFiles, vaultFile)
+					break
+				}
+			}
+		}
+	}
+	return sharedVaultFiles, nil
+}
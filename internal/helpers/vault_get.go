@@ -19,10 +19,11 @@ type VaultInfo struct {
 }
 
 type VaultAccessorInfo struct {
-	Name  string   `json:"name,omitempty"`
-	Email string   `json:"email,omitempty"`
-	Tags  []string `json:"tags,omitempty"`
-	Ref   string   `json:"ref,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	Email       string   `json:"email,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Ref         string   `json:"ref,omitempty"`
+	Fingerprint string   `json:"fingerprint,omitempty"`
 }
 
 type VaultItemInfo struct {
@@ -93,6 +94,7 @@ func GetVaultInfo(vault *vaults.Vault, includeAccessors, encodeValuesToBase64 bo
 					Ref:  "Unknown Accessor",
 				}
 			}
+			accessorInfo.Fingerprint = accessor.Fingerprint()
 			info.Accessors[accessorPubKey] = accessorInfo
 		}
 	}
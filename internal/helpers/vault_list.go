@@ -1,8 +1,10 @@
 package helpers
 
 import (
+	"fmt"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -54,3 +56,48 @@ func ListVaultFiles(dir string, recursive bool) (vaultFiles []string, err error)
 	}
 	return vaultFiles, err
 }
+
+// MatchVaultFiles filters the given vault files (relative paths as returned by ListVaultFiles)
+// using glob patterns. Path segments are matched with path.Match, and a '**' segment matches any number of
+// directories, including none.
+func MatchVaultFiles(vaultFiles []string, patterns []string) ([]string, error) {
+	if len(patterns) == 0 {
+		return vaultFiles, nil
+	}
+	var patternsSegments [][]string
+	for _, pattern := range patterns {
+		segments := strings.Split(strings.TrimPrefix(filepath.ToSlash(pattern), "./"), "/")
+		for _, segment := range segments {
+			if _, err := path.Match(segment, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			}
+		}
+		patternsSegments = append(patternsSegments, segments)
+	}
+	var matched []string
+	for _, vaultFile := range vaultFiles {
+		pathSegments := strings.Split(filepath.ToSlash(vaultFile), "/")
+		for _, segments := range patternsSegments {
+			if matchSegments(segments, pathSegments) {
+				matched = append(matched, vaultFile)
+				break
+			}
+		}
+	}
+	return matched, nil
+}
+
+func matchSegments(patternSegments, pathSegments []string) bool {
+	if len(patternSegments) == 0 {
+		return len(pathSegments) == 0
+	}
+	if patternSegments[0] == "**" {
+		return matchSegments(patternSegments[1:], pathSegments) ||
+			(len(pathSegments) > 0 && matchSegments(patternSegments, pathSegments[1:]))
+	}
+	if len(pathSegments) == 0 {
+		return false
+	}
+	matched, _ := path.Match(patternSegments[0], pathSegments[0])
+	return matched && matchSegments(patternSegments[1:], pathSegments[1:])
+}
@@ -0,0 +1,76 @@
+package helpers
+
+import (
+	"errors"
+	"path"
+	"slices"
+	"testing"
+)
+
+func TestMatchVaultFiles(t *testing.T) {
+	vaultFiles := []string{
+		"root.slv.yaml",
+		"team-a/app.slv.yaml",
+		"team-a/db/creds.slv.yaml",
+		"team-a/db/replica/creds.slv.yaml",
+		"team-b/app.slv.yaml",
+		"team-b/app1.slv.yaml",
+		"team-b/app12.slv.yaml",
+		"special/a*b.slv.yaml",
+		"special/a?b.slv.yaml",
+		"special/a[b].slv.yaml",
+		"special/axb.slv.yaml",
+	}
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []string
+	}{
+		{"no patterns", nil, vaultFiles},
+		{"literal", []string{"team-a/app.slv.yaml"}, []string{"team-a/app.slv.yaml"}},
+		{"leading dot slash", []string{"./root.slv.yaml"}, []string{"root.slv.yaml"}},
+		{"star stays within a directory", []string{"*.slv.yaml"}, []string{"root.slv.yaml"}},
+		{"star in directory", []string{"*/app.slv.yaml"}, []string{"team-a/app.slv.yaml", "team-b/app.slv.yaml"}},
+		{"double star under directory", []string{"team-a/**"}, []string{
+			"team-a/app.slv.yaml", "team-a/db/creds.slv.yaml", "team-a/db/replica/creds.slv.yaml",
+		}},
+		{"double star matches no directory", []string{"**/root.slv.yaml"}, []string{"root.slv.yaml"}},
+		{"double star in the middle", []string{"team-a/**/creds.slv.yaml"}, []string{
+			"team-a/db/creds.slv.yaml", "team-a/db/replica/creds.slv.yaml",
+		}},
+		{"double star alone", []string{"**"}, vaultFiles},
+		{"double star within a segment stays within a directory", []string{"**.slv.yaml"}, []string{"root.slv.yaml"}},
+		{"question mark", []string{"team-b/app?.slv.yaml"}, []string{"team-b/app1.slv.yaml"}},
+		{"question mark does not match separator", []string{"team-a?app.slv.yaml"}, nil},
+		{"star does not match separator", []string{"team-a*"}, nil},
+		{"character class", []string{"special/a[*?]b.slv.yaml"}, []string{"special/a*b.slv.yaml", "special/a?b.slv.yaml"}},
+		{"escaped star", []string{`special/a\*b.slv.yaml`}, []string{"special/a*b.slv.yaml"}},
+		{"escaped question mark", []string{`special/a\?b.slv.yaml`}, []string{"special/a?b.slv.yaml"}},
+		{"escaped bracket", []string{`special/a\[b\].slv.yaml`}, []string{"special/a[b].slv.yaml"}},
+		{"regexp metacharacters are literal", []string{"team-a/app.slv.yam."}, nil},
+		{"several patterns", []string{"root.slv.yaml", "team-b/*"}, []string{
+			"root.slv.yaml", "team-b/app.slv.yaml", "team-b/app1.slv.yaml", "team-b/app12.slv.yaml",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MatchVaultFiles(vaultFiles, tt.patterns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchVaultFilesInvalidPattern(t *testing.T) {
+	for _, pattern := range []string{"team-a/[", `team-a/app\`, "**/[a-"} {
+		t.Run(pattern, func(t *testing.T) {
+			if _, err := MatchVaultFiles([]string{"team-a/app.slv.yaml"}, []string{pattern}); !errors.Is(err, path.ErrBadPattern) {
+				t.Fatalf("got %v, want %v", err, path.ErrBadPattern)
+			}
+		})
+	}
+}
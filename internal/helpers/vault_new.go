@@ -14,6 +14,9 @@ func NewVault(vaultFile, name, k8sNamespace string, enableHash, pq bool, pkStrLi
 		}
 		pubKeys = append(pubKeys, pubKey)
 	}
+	if err := CheckVaultSharing(pubKeys); err != nil {
+		return nil, err
+	}
 	return vaults.New(vaultFile, name, k8sNamespace, enableHash, pq, pubKeys...)
 }
 
@@ -0,0 +1,156 @@
+package helpers
+
+import (
+	"errors"
+	"slices"
+
+	"slv.sh/slv/internal/core/crypto"
+	"slv.sh/slv/internal/core/environments"
+	"slv.sh/slv/internal/core/profiles"
+	"slv.sh/slv/internal/core/session"
+	"slv.sh/slv/internal/core/vaults"
+)
+
+var errNotVaultWriter = errors.New("the current session is not an authorized writer of the vault")
+
+// VaultWriter is an environment that is authorized to sign changes to a vault. Env is nil for writers whose
+// environment is not known.
+type VaultWriter struct {
+	Env        *environments.Environment
+	SigningKey *crypto.VerificationKey
+}
+
+// getTrustedWriters returns the writers that authorize the other writers of vaults: the root and the admins of the
+// active profile if it signs its environments, or the identities of the current session otherwise.
+func getTrustedWriters(sess *session.Session) []*VaultWriter {
+	var trustedWriters []*VaultWriter
+	if profile, err := profiles.GetActiveProfile(); err == nil {
+		if signed, err := profile.IsSigned(); err == nil && signed {
+			root, _ := profile.GetRoot()
+			admins, _ := profile.ListAdmins()
+			for _, env := range append([]*environments.Environment{root}, admins...) {
+				if signingKey, err := env.GetSigningKey(); err == nil {
+					trustedWriters = append(trustedWriters, &VaultWriter{Env: env, SigningKey: signingKey})
+				}
+			}
+			return trustedWriters
+		}
+	}
+	if sess != nil {
+		for _, id := range sess.Identities() {
+			env, _ := id.Env()
+			for _, pq := range []bool{false, true} {
+				if signingKey, err := id.SecretKey().VerificationKey(pq); err == nil {
+					trustedWriters = append(trustedWriters, &VaultWriter{Env: env, SigningKey: signingKey})
+				}
+			}
+		}
+	}
+	return trustedWriters
+}
+
+// getKnownEnvs returns the environments whose signing keys can be told apart: the trusted environments of the active
+// profile, the self environment and those of the identities of the current session.
+func getKnownEnvs(sess *session.Session) []*environments.Environment {
+	var knownEnvs []*environments.Environment
+	if profile, err := profiles.GetActiveProfile(); err == nil {
+		if envs, err := profile.ListEnvs(); err == nil {
+			for _, env := range envs {
+				if profile.VerifyEnv(env) == nil {
+					knownEnvs = append(knownEnvs, env)
+				}
+			}
+		}
+	}
+	if self := environments.GetSelf(); self != nil {
+		knownEnvs = append(knownEnvs, self)
+	}
+	if sess != nil {
+		for _, id := range sess.Identities() {
+			if env, _ := id.Env(); env != nil {
+				knownEnvs = append(knownEnvs, env)
+			}
+		}
+	}
+	return knownEnvs
+}
+
+// GetVaultWriters returns the writers authorized to sign changes to the vault: the root and the admins of the active
+// profile (or the identities of the current session for profiles that do not sign their environments), along with
+// the writers listed in the vault that one of them authorized. Having access to the vault does not make a writer.
+func GetVaultWriters(vault *vaults.Vault) []*VaultWriter {
+	var sess *session.Session
+	if s, err := session.GetSession(); err == nil && s.SecretKey() != nil {
+		sess = s
+	}
+	trustedWriters := getTrustedWriters(sess)
+	knownEnvs := getKnownEnvs(sess)
+	var writers []*VaultWriter
+	for _, signingKey := range vault.Writers(GetSigningKeys(trustedWriters)) {
+		writer := &VaultWriter{SigningKey: signingKey}
+		for _, trustedWriter := range trustedWriters {
+			if trustedWriter.SigningKey.Equals(signingKey) {
+				writer.Env = trustedWriter.Env
+				break
+			}
+		}
+		for _, env := range knownEnvs {
+			if writer.Env != nil {
+				break
+			}
+			if envSigningKey, err := env.GetSigningKey(); err == nil && envSigningKey.Equals(signingKey) {
+				writer.Env = env
+			}
+		}
+		writers = append(writers, writer)
+	}
+	return writers
+}
+
+// AddVaultWriter lists the signing key as a writer of the vault, authorized by the first of the secret keys that
+// belongs to an authorized writer of the vault.
+func AddVaultWriter(vault *vaults.Vault, signingKey *crypto.VerificationKey, secretKeys []*crypto.SecretKey) error {
+	writers := GetSigningKeys(GetVaultWriters(vault))
+	for _, secretKey := range secretKeys {
+		for _, pq := range []bool{false, true} {
+			if secretKey == nil {
+				continue
+			}
+			if verificationKey, err := secretKey.VerificationKey(pq); err == nil && slices.ContainsFunc(writers, verificationKey.Equals) {
+				return vault.AddWriter(signingKey, secretKey, pq)
+			}
+		}
+	}
+	return errNotVaultWriter
+}
+
+func sessionIdentity(sess *session.Session, publicKeyStr string) *session.Identity {
+	if sess == nil {
+		return nil
+	}
+	return sess.Identity(publicKeyStr)
+}
+
+// GetSigningKeys returns the signing keys of the given writers.
+func GetSigningKeys(writers []*VaultWriter) []*crypto.VerificationKey {
+	signingKeys := make([]*crypto.VerificationKey, 0, len(writers))
+	for _, writer := range writers {
+		signingKeys = append(signingKeys, writer.SigningKey)
+	}
+	return signingKeys
+}
+
+// SetVaultSigner makes the environment of the first of the given secret keys that has access to the vault sign
+// the subsequent changes to the vault.
+func SetVaultSigner(vault *vaults.Vault, secretKeys ...*crypto.SecretKey) (bool, error) {
+	for _, secretKey := range secretKeys {
+		if secretKey == nil || !vault.IsAccessibleBy(secretKey) {
+			continue
+		}
+		if err := vault.SetSigner(secretKey); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
@@ -61,6 +61,17 @@ func (r *SLV) validateSLV() (err error) {
 		slvlog.Error(err, "failed to unlock vault", "name", r.Name, "error", err.Error())
 		return err
 	}
+	var writers []*crypto.VerificationKey
+	if writers, err = session.GetK8sVaultWriters(); err != nil {
+		slvlog.Error(err, "failed to retrieve vault writers", "name", r.Name, "error", err.Error())
+		return err
+	}
+	if len(writers) > 0 {
+		if err = vault.CheckSignatures(vault.Writers(writers)); err != nil {
+			slvlog.Error(err, "rejected vault signatures", "name", r.Name, "error", err.Error())
+			return err
+		}
+	}
 	if _, err = vault.GetAllValues(); err != nil {
 		slvlog.Error(err, "failed to read all secrets", "name", r.Name, "error", err.Error())
 		return err
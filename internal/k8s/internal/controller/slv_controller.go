@@ -122,6 +122,13 @@ func (r *SLVReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 			return r.returnError(ctx, &slvObj, &logger, err, "Failed to unlock vault")
 		}
 	}
+	if writers, err := session.GetK8sVaultWriters(); err != nil {
+		return r.returnError(ctx, &slvObj, &logger, err, "Failed to get vault writers")
+	} else if len(writers) > 0 {
+		if err = vault.CheckSignatures(vault.Writers(writers)); err != nil {
+			return r.returnError(ctx, &slvObj, &logger, err, "Rejected vault signatures")
+		}
+	}
 	slvSecretMap, err := vault.GetAllValues()
 	if err != nil {
 		return r.returnError(ctx, &slvObj, &logger, err, "Failed to get all secrets from vault")
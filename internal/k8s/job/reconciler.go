@@ -16,6 +16,7 @@ import (
 	"k8s.io/client-go/rest"
 	"slv.sh/slv/internal/core/config"
 	"slv.sh/slv/internal/core/crypto"
+	"slv.sh/slv/internal/core/session"
 	slvv1 "slv.sh/slv/internal/k8s/api/v1"
 )
 
@@ -65,6 +66,15 @@ func toSecret(clientset *kubernetes.Clientset, secretKey *crypto.SecretKey, slvO
 	if err = vault.Unlock(secretKey); err != nil {
 		return err
 	}
+	writers, err := session.GetK8sVaultWriters()
+	if err != nil {
+		return err
+	}
+	if len(writers) > 0 {
+		if err = vault.CheckSignatures(vault.Writers(writers)); err != nil {
+			return err
+		}
+	}
 	slvSecretMap, err := vault.GetAllValues()
 	if err != nil {
 		return err
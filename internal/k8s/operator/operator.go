@@ -152,6 +152,17 @@ func Run() {
 		setupLog.Error(err, "unable to initialize slv environment")
 		os.Exit(1)
 	}
+	// The watcher lives as long as the operator, so that rotated secret keys are picked up without restarting.
+	if _, err := session.WatchSecretFiles(func(err error) {
+		if err != nil {
+			setupLog.Error(err, "unable to reload slv environment")
+		} else {
+			setupLog.Info("reloaded slv environment from the changed secret files")
+		}
+	}); err != nil {
+		setupLog.Error(err, "unable to watch slv environment secret files")
+		os.Exit(1)
+	}
 
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
@@ -15,6 +15,14 @@ func (nep *NewEnvironmentPage) createEnvironment() {
 	var secretKey *crypto.SecretKey
 	var err error
 
+	// Respect the settings of the active profile
+	if profile, err := profiles.GetActiveProfile(); err == nil {
+		if err = profile.CheckCreateEnv(); err != nil {
+			nep.ShowError(err.Error())
+			return
+		}
+	}
+
 	// Handle direct service creation
 	if nep.selectedProvider == "direct" {
 		env, secretKey, err = environments.New(nep.envName, nep.selectedType, nep.quantumSafe)
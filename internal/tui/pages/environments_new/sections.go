@@ -126,6 +126,16 @@ func (nep *NewEnvironmentPage) showProviderSelection() {
 		providerDesc := envproviders.GetDesc(providerId)
 		pid := providerId // Capture for closure
 
+		// Providers without a secret binding can only hold the self environment
+		if !envproviders.IsBindingRequired(providerId) {
+			nep.providerList.AddItem(fmt.Sprintf("%s (Self/User)", providerName), providerDesc, rune(providerName[0]), func() {
+				nep.selectedProvider = pid
+				nep.selectedType = environments.USER
+				nep.showMetadataForm()
+			})
+			continue
+		}
+
 		nep.providerList.AddItem(fmt.Sprintf("%s (Service)", providerName), providerDesc, rune(providerName[0]), func() {
 			nep.selectedProvider = pid
 			nep.selectedType = environments.SERVICE
@@ -4,16 +4,19 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
 	"github.com/rivo/tview"
+	"slv.sh/slv/internal/core/audit"
 	"slv.sh/slv/internal/core/config"
 	"slv.sh/slv/internal/core/crypto"
 	"slv.sh/slv/internal/core/environments"
 	"slv.sh/slv/internal/core/profiles"
 	"slv.sh/slv/internal/core/session"
 	"slv.sh/slv/internal/core/vaults"
+	"slv.sh/slv/internal/helpers"
 )
 
 func (vep *VaultEditPage) searchEnvironments(query string) {
@@ -65,6 +68,15 @@ func (vep *VaultEditPage) searchEnvironments(query string) {
 		matchingEnvs = envs
 	}
 
+	// Environments that are not signed by the root or an admin of the profile cannot be shared with
+	matchingEnvs = slices.DeleteFunc(matchingEnvs, func(env *environments.Environment) bool {
+		return profile.VerifyEnv(env) != nil
+	})
+	if len(matchingEnvs) == 0 {
+		vep.searchResults.AddItem("❌ No trusted environment found in the profile", "", 0, nil)
+		return
+	}
+
 	// Sort environments by name for consistent display order
 	sort.Slice(matchingEnvs, func(i, j int) bool {
 		return matchingEnvs[i].Name < matchingEnvs[j].Name
@@ -213,12 +225,12 @@ func (vep *VaultEditPage) editVaultFromForm() {
 
 		return
 	}
-	secretKey, err := session.GetSecretKey()
+	secretKeys, err := session.GetSecretKeys()
 	if err != nil {
 		vep.showError(fmt.Sprintf("error editing vault: %v", err))
 		return
 	}
-	if err := vep.vault.Unlock(secretKey); err != nil {
+	if err := vep.vault.Unlock(secretKeys...); err != nil {
 		vep.showError(fmt.Sprintf("error editing vault: %v", err))
 		return
 	}
@@ -236,11 +248,31 @@ func (vep *VaultEditPage) editVaultFromForm() {
 		return
 	}
 	vep.ShowInfo(fmt.Sprintf("%v,%v", publicKeys, existingKeys))
-	for _, key := range publicKeys {
-		if !containsPublicKey(existingKeys, key) {
-			vep.vault.Share(&key)
+	var newKeys []*crypto.PublicKey
+	for i := range publicKeys {
+		if !containsPublicKey(existingKeys, publicKeys[i]) {
+			newKeys = append(newKeys, &publicKeys[i])
+		}
+	}
+	if err = helpers.CheckVaultSharing(newKeys); err != nil {
+		vep.showError(err.Error())
+		return
+	}
+	if len(newKeys) > 0 {
+		var grantedKeys []string
+		for _, key := range newKeys {
+			if keyStr, err := key.String(); err == nil {
+				grantedKeys = append(grantedKeys, keyStr)
+			}
+		}
+		if err := audit.Record(audit.TUI, &audit.Entry{Action: audit.Grant, Vault: vep.filePath, Envs: grantedKeys}); err != nil {
+			vep.showError(fmt.Sprintf("error writing audit log: %v", err))
+			return
 		}
 	}
+	for _, key := range newKeys {
+		vep.vault.Share(key)
+	}
 
 	// Revoke keys that are no longer granted
 	var keysToRevoke []*crypto.PublicKey
@@ -249,6 +281,18 @@ func (vep *VaultEditPage) editVaultFromForm() {
 			keysToRevoke = append(keysToRevoke, &key)
 		}
 	}
+	if len(keysToRevoke) > 0 {
+		var revokedKeys []string
+		for _, key := range keysToRevoke {
+			if keyStr, err := key.String(); err == nil {
+				revokedKeys = append(revokedKeys, keyStr)
+			}
+		}
+		if err := audit.Record(audit.TUI, &audit.Entry{Action: audit.Revoke, Vault: vep.filePath, Envs: revokedKeys}); err != nil {
+			vep.showError(fmt.Sprintf("error writing audit log: %v", err))
+			return
+		}
+	}
 	if err := vep.vault.Revoke(keysToRevoke, false); err != nil {
 		vep.showError(fmt.Sprintf("error editing vault: %v", err))
 		return
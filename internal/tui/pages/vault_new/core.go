@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -14,6 +15,7 @@ import (
 	"slv.sh/slv/internal/core/profiles"
 	"slv.sh/slv/internal/core/session"
 	"slv.sh/slv/internal/core/vaults"
+	"slv.sh/slv/internal/helpers"
 )
 
 func (vnp *VaultNewPage) searchEnvironments(query string) {
@@ -65,6 +67,15 @@ func (vnp *VaultNewPage) searchEnvironments(query string) {
 		matchingEnvs = envs
 	}
 
+	// Environments that are not signed by the root or an admin of the profile cannot be shared with
+	matchingEnvs = slices.DeleteFunc(matchingEnvs, func(env *environments.Environment) bool {
+		return profile.VerifyEnv(env) != nil
+	})
+	if len(matchingEnvs) == 0 {
+		vnp.searchResults.AddItem("❌ No trusted environment found in the profile", "", 0, nil)
+		return
+	}
+
 	// Sort environments by name for consistent display order
 	sort.Slice(matchingEnvs, func(i, j int) bool {
 		return matchingEnvs[i].Name < matchingEnvs[j].Name
@@ -203,6 +214,11 @@ func (vnp *VaultNewPage) createVaultFromForm() {
 		}
 	}
 
+	if err := helpers.CheckVaultSharing(publicKeys); err != nil {
+		vnp.showError(err.Error())
+		return
+	}
+
 	// Create the vault
 	vault, err := vaults.New(vaultFilePath, vaultName, namespace, enableHashing, quantumSafe, publicKeys...)
 	if err != nil {
@@ -210,6 +226,19 @@ func (vnp *VaultNewPage) createVaultFromForm() {
 		return
 	}
 
+	// Sign the new vault when the session environment has access to it
+	if secretKey, _ := session.GetSecretKey(); secretKey != nil {
+		if signed, err := helpers.SetVaultSigner(vault, secretKey); err != nil {
+			vnp.showError(fmt.Sprintf("Failed to sign vault: %v", err))
+			return
+		} else if signed {
+			if err = vault.Sign(); err != nil {
+				vnp.showError(fmt.Sprintf("Failed to sign vault: %v", err))
+				return
+			}
+		}
+	}
+
 	// Show success message
 	vnp.showSuccess(fmt.Sprintf("Vault '%s' created successfully at %s", vaultName, vaultFilePath))
 
@@ -3,6 +3,7 @@ package vault_view
 import (
 	"fmt"
 
+	"slv.sh/slv/internal/core/audit"
 	"slv.sh/slv/internal/core/session"
 	"slv.sh/slv/internal/core/vaults"
 )
@@ -48,13 +49,16 @@ func (vvp *VaultViewPage) unlockVault() {
 	}
 
 	// Attempt to unlock the vault
-	secretKey, err := session.GetSecretKey()
+	secretKeys, err := session.GetSecretKeys()
 	if err != nil {
 		vvp.ShowError(fmt.Sprintf("Error getting secret key: %v", err))
 		return
 	}
 
-	err = vvp.vault.Unlock(secretKey)
+	if !vvp.recordAudit(&audit.Entry{Action: audit.Unlock, Vault: vvp.filePath}) {
+		return
+	}
+	err = vvp.vault.Unlock(secretKeys...)
 	if err != nil {
 		vvp.ShowError(fmt.Sprintf("Error unlocking vault: %v", err))
 		return
@@ -106,7 +110,13 @@ func (vvp *VaultViewPage) removeSecretItem() {
 				return
 			}
 
-			vvp.vault.DeleteItem(itemKey)
+			if !vvp.recordAudit(&audit.Entry{Action: audit.Delete, Vault: vvp.filePath, Items: []string{itemKey}}) {
+				return
+			}
+			if err := vvp.vault.DeleteItem(itemKey); err != nil {
+				vvp.ShowError(fmt.Sprintf("Error deleting item: %v", err))
+				return
+			}
 			vvp.SaveNavigationState()
 			vvp.GetTUI().GetNavigation().ShowVaultDetailsWithVault(vvp.vault, vvp.filePath, true)
 		},
@@ -119,3 +129,12 @@ func (vvp *VaultViewPage) removeSecretItem() {
 		},
 	)
 }
+
+// recordAudit records the given entry in the audit log and shows an error if it cannot be recorded
+func (vvp *VaultViewPage) recordAudit(entry *audit.Entry) bool {
+	if err := audit.Record(audit.TUI, entry); err != nil {
+		vvp.ShowError(fmt.Sprintf("Error writing audit log: %v", err))
+		return false
+	}
+	return true
+}
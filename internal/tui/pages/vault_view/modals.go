@@ -5,6 +5,7 @@ import (
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
+	"slv.sh/slv/internal/core/audit"
 	"slv.sh/slv/internal/tui/theme"
 )
 
@@ -42,6 +43,9 @@ func (vvp *VaultViewPage) showEditSecretItemModal() {
 		value := valueTextArea.GetText()
 		encrypted := encryptedCheckbox.IsChecked()
 
+		if !vvp.recordAudit(&audit.Entry{Action: audit.Put, Vault: vvp.filePath, Items: []string{name}}) {
+			return
+		}
 		if err := vvp.vault.Put(name, []byte(value), encrypted); err != nil {
 			vvp.ShowError(fmt.Sprintf("Error updating item: %v", err))
 			return
@@ -86,6 +90,9 @@ func (fn *FormNavigation) showAddItemModal() {
 			return
 		}
 
+		if !fn.vvp.recordAudit(&audit.Entry{Action: audit.Put, Vault: fn.vvp.filePath, Items: []string{name}}) {
+			return
+		}
 		if err := fn.vvp.vault.Put(name, []byte(value), encrypted); err != nil {
 			fn.vvp.ShowError(err.Error())
 			return
@@ -202,7 +209,7 @@ func (vvp *VaultViewPage) showItemDetailsModal(name, itemType, value string) {
 }
 
 // showAccessorDetailsModal shows a modal with full accessor details
-func (vvp *VaultViewPage) showAccessorDetailsModal(accessorType, name, email, publicKey string) {
+func (vvp *VaultViewPage) showAccessorDetailsModal(accessorType, name, email, fingerprint, publicKey string) {
 	colors := theme.GetCurrentPalette()
 
 	// Create content container
@@ -230,6 +237,14 @@ func (vvp *VaultViewPage) showAccessorDetailsModal(accessorType, name, email, pu
 			SetTextColor(colors.TextPrimary), 1, 1, false)
 	}
 
+	// Fingerprint field
+	if fingerprint != "" {
+		content.AddItem(tview.NewTextView().
+			SetText(fmt.Sprintf("[::b]Fingerprint[::-]: %s", fingerprint)).
+			SetDynamicColors(true).
+			SetTextColor(colors.TextPrimary), 1, 1, false)
+	}
+
 	// Public Key label
 	content.AddItem(tview.NewTextView().
 		SetText("[::b]Public Key[::-]:").
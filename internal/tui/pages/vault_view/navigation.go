@@ -228,7 +228,8 @@ func (fn *FormNavigation) handleAccessorsInputCapture(event *tcell.EventKey) *tc
 			typeCell := fn.vvp.accessorsTable.GetCell(row, 0)
 			nameCell := fn.vvp.accessorsTable.GetCell(row, 1)
 			emailCell := fn.vvp.accessorsTable.GetCell(row, 2)
-			publicKeyCell := fn.vvp.accessorsTable.GetCell(row, 3)
+			fingerprintCell := fn.vvp.accessorsTable.GetCell(row, 3)
+			publicKeyCell := fn.vvp.accessorsTable.GetCell(row, 4)
 
 			if publicKeyCell != nil {
 				accessorType := ""
@@ -243,9 +244,13 @@ func (fn *FormNavigation) handleAccessorsInputCapture(event *tcell.EventKey) *tc
 				if emailCell != nil {
 					email = emailCell.Text
 				}
+				fingerprint := ""
+				if fingerprintCell != nil {
+					fingerprint = fingerprintCell.Text
+				}
 				publicKey := publicKeyCell.Text
 
-				fn.vvp.showAccessorDetailsModal(accessorType, name, email, publicKey)
+				fn.vvp.showAccessorDetailsModal(accessorType, name, email, fingerprint, publicKey)
 			}
 		}
 		return nil
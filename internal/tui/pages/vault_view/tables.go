@@ -66,7 +66,8 @@ func (vvp *VaultViewPage) createAccessorsTable() tview.Primitive {
 	table.SetCell(0, 0, tview.NewTableCell("Type").SetTextColor(colors.TableHeader).SetAlign(tview.AlignLeft).SetSelectable(false).SetMaxWidth(8))
 	table.SetCell(0, 1, tview.NewTableCell("Name").SetTextColor(colors.TableHeader).SetAlign(tview.AlignLeft).SetSelectable(false).SetMaxWidth(30))
 	table.SetCell(0, 2, tview.NewTableCell("Email").SetTextColor(colors.TableHeader).SetAlign(tview.AlignLeft).SetSelectable(false).SetMaxWidth(30))
-	table.SetCell(0, 3, tview.NewTableCell("Public Key").SetTextColor(colors.TableHeader).SetAlign(tview.AlignLeft).SetSelectable(false))
+	table.SetCell(0, 3, tview.NewTableCell("Fingerprint").SetTextColor(colors.TableHeader).SetAlign(tview.AlignLeft).SetSelectable(false).SetMaxWidth(19))
+	table.SetCell(0, 4, tview.NewTableCell("Public Key").SetTextColor(colors.TableHeader).SetAlign(tview.AlignLeft).SetSelectable(false))
 
 	accessors, err := vvp.vault.ListAccessors()
 	if err != nil || len(accessors) == 0 {
@@ -74,6 +75,7 @@ func (vvp *VaultViewPage) createAccessorsTable() tview.Primitive {
 		table.SetCell(1, 1, tview.NewTableCell("").SetTextColor(colors.TableEmpty))
 		table.SetCell(1, 2, tview.NewTableCell("").SetTextColor(colors.TableEmpty))
 		table.SetCell(1, 3, tview.NewTableCell("").SetTextColor(colors.TableEmpty))
+		table.SetCell(1, 4, tview.NewTableCell("").SetTextColor(colors.TableEmpty))
 		vvp.accessorsTable = table
 		return table
 	}
@@ -126,7 +128,8 @@ func (vvp *VaultViewPage) createAccessorsTable() tview.Primitive {
 		table.SetCell(row, 0, tview.NewTableCell(accessorType).SetTextColor(colors.TableType).SetMaxWidth(8))
 		table.SetCell(row, 1, tview.NewTableCell(accessorName).SetTextColor(colors.TableName).SetMaxWidth(30))
 		table.SetCell(row, 2, tview.NewTableCell(accessorEmail).SetTextColor(colors.TableEmail).SetMaxWidth(30))
-		table.SetCell(row, 3, tview.NewTableCell(accessorPubKey).SetTextColor(colors.TableKey))
+		table.SetCell(row, 3, tview.NewTableCell(accessor.Fingerprint()).SetTextColor(colors.TableKey).SetMaxWidth(19))
+		table.SetCell(row, 4, tview.NewTableCell(accessorPubKey).SetTextColor(colors.TableKey))
 		row++
 	}
 